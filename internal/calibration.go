@@ -0,0 +1,62 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Calibration is the outcome of rating a sample puzzle whose CSV file is named
+// after the DifficultyTier it is published under, e.g. samples/expert.csv.
+type Calibration struct {
+	File     string         // Path of the sample CSV file
+	Expected DifficultyTier // Tier named by the file
+	Rating   Rating         // Rating of the sample, if it could be rated
+	Err      error          // Problem loading or rating the sample, if any
+}
+
+// Matches returns whether the sample was rated in its named tier.
+func (c Calibration) Matches() bool {
+	return c.Err == nil && c.Rating.Tier == c.Expected
+}
+
+// String returns the Calibration in the form
+// "samples/expert.csv: expected expert, rated 4.6 expert (XY-Wing, ...)".
+func (c Calibration) String() string {
+	if c.Err != nil {
+		return fmt.Sprintf("%s: expected %s, %v", c.File, c.Expected, c.Err)
+	}
+	return fmt.Sprintf("%s: expected %s, rated %s", c.File, c.Expected, c.Rating)
+}
+
+// Calibrate rates every sample CSV file in the directory named after a
+// DifficultyTier, in order of the tiers, so the thresholds can be checked against
+// the tiers the samples are published under.  Files named otherwise are ignored.
+// Returns an error if the directory cannot be read.
+func (r *Rater) Calibrate(directory string) ([]Calibration, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+	calibrations := []Calibration{}
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".csv")
+		tier, err := ParseDifficultyTier(name)
+		if entry.IsDir() || name == entry.Name() || err != nil {
+			continue
+		}
+		calibration := Calibration{File: filepath.Join(directory, entry.Name()), Expected: tier}
+		grid, err := NewGridFromCsv(calibration.File)
+		if err == nil {
+			calibration.Rating, err = r.Rate(grid)
+		}
+		calibration.Err = err
+		calibrations = append(calibrations, calibration)
+	}
+	sort.SliceStable(calibrations, func(i, j int) bool {
+		return calibrations[i].Expected < calibrations[j].Expected
+	})
+	return calibrations, nil
+}
@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalibration_String(t *testing.T) {
+	calibration := Calibration{
+		File:     "samples/expert.csv",
+		Expected: ExpertTier,
+		Rating:   Rating{Score: 4.2, Tier: ExpertTier, Hardest: 4.2, Technique: TechniqueXYWing, HardSteps: 1, Steps: 50, Status: Solved},
+	}
+	assert.True(t, calibration.Matches())
+	assert.Equal(t, "samples/expert.csv: expected expert, rated 4.2 expert (XY-Wing, 1 hard steps of 50, Pattern Logic)", calibration.String())
+	calibration.Rating.Tier = HardTier
+	assert.False(t, calibration.Matches())
+	calibration.Err = ErrUnsolvable
+	assert.False(t, calibration.Matches())
+	assert.Equal(t, "samples/expert.csv: expected expert, sudoku is unsolvable", calibration.String())
+}
+
+func TestRater_Calibrate(t *testing.T) {
+
+	// Create a temporary samples directory, where the samples/hard.csv puzzle
+	// only needs hidden singles
+	directory, err := os.MkdirTemp("", "calibrate-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+	puzzle := testGridCsv()
+	for name, content := range map[string]string{
+		"easy.csv":   strings.Join(puzzle[:], ""),
+		"hard.csv":   strings.Join(puzzle[:], ""),
+		"master.csv": strings.Join(puzzle[:8], ""),
+		"notes.csv":  strings.Join(puzzle[:], ""),
+		"easy.txt":   strings.Join(puzzle[:], ""),
+	} {
+		if err := os.WriteFile(filepath.Join(directory, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Verify only the samples named after a tier are rated, in order of the tiers
+	calibrations, err := NewRater(NewSolver(50, false)).Calibrate(directory)
+	assert.Nil(t, err)
+	assert.Len(t, calibrations, 3)
+	assert.Equal(t, filepath.Join(directory, "easy.csv"), calibrations[0].File)
+	assert.True(t, calibrations[0].Matches())
+	assert.Equal(t, HardTier, calibrations[1].Expected)
+	assert.Equal(t, EasyTier, calibrations[1].Rating.Tier)
+	assert.False(t, calibrations[1].Matches())
+	assert.Equal(t, MasterTier, calibrations[2].Expected)
+	assert.ErrorContains(t, calibrations[2].Err, "exactly nine rows expected")
+
+	// Verify a missing directory is reported
+	_, err = NewRater(NewSolver(50, false)).Calibrate(filepath.Join(directory, "missing"))
+	assert.Error(t, err)
+}
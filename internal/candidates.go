@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// candidateMask is a set of values where bit (value-1) is set for each value in
+// the set, allowing the possible values of Cells to be combined cheaply.
+type candidateMask uint16
+
+// maskOf returns the candidateMask holding the specified values.
+func maskOf(values ...int) candidateMask {
+	mask := candidateMask(0)
+	for _, value := range values {
+		mask |= 1 << (value - 1)
+	}
+	return mask
+}
+
+// has returns whether the value is in the set.
+func (m candidateMask) has(value int) bool {
+	return m&(1<<(value-1)) != 0
+}
+
+// count returns the number of values in the set.
+func (m candidateMask) count() int {
+	return bits.OnesCount16(uint16(m))
+}
+
+// values returns the values in the set in order.
+func (m candidateMask) values() []int {
+	values := []int{}
+	for value := 1; value <= 9; value++ {
+		if m.has(value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// String returns the values in the set in the form "{3,7}".
+func (m candidateMask) String() string {
+	values := []string{}
+	for _, value := range m.values() {
+		values = append(values, fmt.Sprint(value))
+	}
+	return "{" + strings.Join(values, ",") + "}"
+}
+
+// formatPositions returns the Positions in the form "r1c2,r1c5".
+func formatPositions(positions []Position) string {
+	formatted := make([]string, len(positions))
+	for index, position := range positions {
+		formatted[index] = position.String()
+	}
+	return strings.Join(formatted, ",")
+}
+
+// combinations calls visit with every combination of k indexes from 0..n-1 in
+// lexicographic order, stopping early if visit returns true.  Returns whether
+// visit stopped the iteration.
+func combinations(n int, k int, visit func(indexes []int) bool) bool {
+	if k > n || k <= 0 {
+		return false
+	}
+	indexes := make([]int, k)
+	for index := range indexes {
+		indexes[index] = index
+	}
+	for {
+		if visit(indexes) {
+			return true
+		}
+
+		// Advance the right-most index which has room to move
+		index := k - 1
+		for index >= 0 && indexes[index] == n-k+index {
+			index--
+		}
+		if index < 0 {
+			return false
+		}
+		indexes[index]++
+		for next := index + 1; next < k; next++ {
+			indexes[next] = indexes[next-1] + 1
+		}
+	}
+}
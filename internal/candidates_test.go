@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCandidateMask(t *testing.T) {
+	mask := maskOf(3, 7, 9)
+	assert.True(t, mask.has(3))
+	assert.False(t, mask.has(4))
+	assert.Equal(t, 3, mask.count())
+	assert.Equal(t, []int{3, 7, 9}, mask.values())
+	assert.Equal(t, "{3,7,9}", mask.String())
+	assert.Equal(t, "{}", candidateMask(0).String())
+}
+
+func TestCell_possibleMask(t *testing.T) {
+	cell := &Cell{possible: evenPossibleValues}
+	assert.Equal(t, maskOf(2, 4, 6, 8), cell.possibleMask())
+}
+
+func TestFormatPositions(t *testing.T) {
+	assert.Equal(t, "r1c2,r1c5", formatPositions([]Position{{Row: 0, Col: 1}, {Row: 0, Col: 4}}))
+	assert.Equal(t, "", formatPositions(nil))
+}
+
+func TestCombinations(t *testing.T) {
+	visited := [][]int{}
+	stopped := combinations(4, 2, func(indexes []int) bool {
+		visited = append(visited, append([]int{}, indexes...))
+		return false
+	})
+	assert.False(t, stopped)
+	assert.Equal(t, [][]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}}, visited)
+
+	// Stop early once the visit returns true
+	count := 0
+	stopped = combinations(5, 3, func(indexes []int) bool {
+		count++
+		return count == 2
+	})
+	assert.True(t, stopped)
+	assert.Equal(t, 2, count)
+
+	// Nothing to visit when too few items
+	assert.False(t, combinations(2, 3, func(indexes []int) bool { return true }))
+}
@@ -20,6 +20,16 @@ func NewCell() *Cell {
 	}
 }
 
+// Copy returns an independent copy of the Cell's current value and possible values.
+func (c *Cell) Copy() *Cell {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return &Cell{
+		value:    c.value,
+		possible: c.possible,
+	}
+}
+
 // GetValue returns the current known value of the Cell or 0 if unknown.
 func (c *Cell) GetValue() int {
 	c.mutex.RLock()
@@ -52,6 +62,19 @@ func (c *Cell) GetPossibleValues() []int {
 	return possibleValues
 }
 
+// possibleMask returns the possible values of the Cell as a candidateMask.
+func (c *Cell) possibleMask() candidateMask {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	mask := candidateMask(0)
+	for i := 0; i < 9; i++ {
+		if c.possible[i] {
+			mask |= 1 << i
+		}
+	}
+	return mask
+}
+
 // IsPossibleValue is a convenience function that returns a boolean indication
 // of whether the specified value is still possible.
 func (c *Cell) IsPossibleValue(value int) bool {
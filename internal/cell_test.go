@@ -66,3 +66,12 @@ func TestCell_EliminateValue(t *testing.T) {
 	cell.EliminateValue(8)
 	assert.Equal(t, [9]bool{false, true, false, false, false, true, false, false, false}, cell.possible)
 }
+
+func TestCell_Copy(t *testing.T) {
+	cell := &Cell{value: 0, possible: evenPossibleValues}
+	cellCopy := cell.Copy()
+	assert.Equal(t, 0, cellCopy.value)
+	assert.Equal(t, evenPossibleValues, cellCopy.possible)
+	cellCopy.EliminateValue(4)
+	assert.True(t, cell.IsPossibleValue(4)) // Original is unaffected
+}
@@ -0,0 +1,201 @@
+package internal
+
+// The exact-cover matrix has one column per constraint which must be satisfied
+// exactly once, and one row per candidate placement of a value in a Cell.
+const (
+	dlxCellConstraints = 0                       // Each Cell holds exactly one value
+	dlxRowConstraints  = dlxCellConstraints + 81 // Each Row holds each value exactly once
+	dlxColConstraints  = dlxRowConstraints + 81  // Each Column holds each value exactly once
+	dlxBoxConstraints  = dlxColConstraints + 81  // Each Group holds each value exactly once
+	dlxColumns         = dlxBoxConstraints + 81  // Total number of constraint columns (324)
+)
+
+// DancingLinks is a brute-force engine which solves a Grid by modelling it as an
+// exact-cover problem and searching it with Knuth's Algorithm X using the
+// "Dancing Links" technique.  It is fast and always correct for valid puzzles.
+type DancingLinks struct{}
+
+// NewDancingLinks returns a DancingLinks solving engine.
+func NewDancingLinks() *DancingLinks {
+	return &DancingLinks{}
+}
+
+// Solve does an in-place update to the specified Grid by setting the values of
+// the first solution found.  Returns false, leaving the Grid untouched, if the
+// Grid has no solution.
+func (d *DancingLinks) Solve(grid *Grid) bool {
+	matrix := newDlxMatrix(grid)
+	if matrix.search(1) == 0 {
+		return false
+	}
+	for _, placement := range matrix.solution {
+		if grid.GetCell(placement.row, placement.col).GetValue() == 0 {
+			grid.SetValue(placement.row, placement.col, placement.value)
+		}
+	}
+	return true
+}
+
+// dlxNode is a single node in the toroidal doubly-linked lists of the matrix.
+// The first dlxColumns+1 nodes are the root and column headers.
+type dlxNode struct {
+	left, right, up, down int // Indexes of the neighbouring nodes
+	column                int // Index of the column header node
+	placement             int // Index of the placement represented by the row
+}
+
+// dlxPlacement is the value placed in a Cell by a single matrix row.
+type dlxPlacement struct {
+	row, col, value int
+}
+
+// dlxMatrix holds the exact-cover matrix for a Grid and the state of a search.
+type dlxMatrix struct {
+	nodes      []dlxNode      // Root (0), column headers (1..324), then row nodes
+	sizes      []int          // Number of row nodes in each column
+	placements []dlxPlacement // Placement represented by each matrix row
+	partial    []int          // Placements chosen along the current search path
+	solution   []dlxPlacement // Placements of the first solution found
+}
+
+// newDlxMatrix returns the exact-cover matrix for the specified Grid with one row
+// per known value and per possible value of each unknown Cell.
+func newDlxMatrix(grid *Grid) *dlxMatrix {
+
+	// Create the root and column header nodes linked in a circle
+	m := &dlxMatrix{
+		nodes: make([]dlxNode, dlxColumns+1, (dlxColumns+1)+729*4),
+		sizes: make([]int, dlxColumns+1),
+	}
+	for index := 0; index <= dlxColumns; index++ {
+		m.nodes[index] = dlxNode{
+			left:   (index + dlxColumns) % (dlxColumns + 1),
+			right:  (index + 1) % (dlxColumns + 1),
+			up:     index,
+			down:   index,
+			column: index,
+		}
+	}
+
+	// Add a row for every value each Cell may still hold
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			cell := grid.GetCell(row, col)
+			if value := cell.GetValue(); value != 0 {
+				m.addRow(row, col, value)
+				continue
+			}
+			for _, value := range cell.GetPossibleValues() {
+				m.addRow(row, col, value)
+			}
+		}
+	}
+	return m
+}
+
+// addRow appends a matrix row covering the four constraints satisfied by placing
+// the value in the Cell at row/col.
+func (m *dlxMatrix) addRow(row int, col int, value int) {
+	box := (row/3)*3 + col/3
+	digit := value - 1
+	columns := [4]int{
+		dlxCellConstraints + row*9 + col,
+		dlxRowConstraints + row*9 + digit,
+		dlxColConstraints + col*9 + digit,
+		dlxBoxConstraints + box*9 + digit,
+	}
+
+	// Link each new node into its column and into a circle with its siblings
+	placement := len(m.placements)
+	m.placements = append(m.placements, dlxPlacement{row: row, col: col, value: value})
+	first := len(m.nodes)
+	for index, column := range columns {
+		header := column + 1
+		node := first + index
+		m.nodes = append(m.nodes, dlxNode{
+			left:      first + (index+3)%4,
+			right:     first + (index+1)%4,
+			up:        m.nodes[header].up,
+			down:      header,
+			column:    header,
+			placement: placement,
+		})
+		m.nodes[m.nodes[header].up].down = node
+		m.nodes[header].up = node
+		m.sizes[header]++
+	}
+}
+
+// search performs Algorithm X until the specified number of solutions has been
+// found, recording the first, and returns the number of solutions found.  A limit
+// of zero or less searches for all solutions.
+func (m *dlxMatrix) search(limit int) int {
+
+	// An empty header list means every constraint is satisfied
+	if m.nodes[0].right == 0 {
+		if m.solution == nil {
+			m.solution = make([]dlxPlacement, len(m.partial))
+			for index, placement := range m.partial {
+				m.solution[index] = m.placements[placement]
+			}
+		}
+		return 1
+	}
+
+	// Choose the column with the fewest rows to keep the search narrow
+	column := m.nodes[0].right
+	for header := m.nodes[column].right; header != 0; header = m.nodes[header].right {
+		if m.sizes[header] < m.sizes[column] {
+			column = header
+		}
+	}
+	if m.sizes[column] == 0 {
+		return 0
+	}
+
+	// Try each row which satisfies the chosen column
+	found := 0
+	m.cover(column)
+	for node := m.nodes[column].down; node != column; node = m.nodes[node].down {
+		m.partial = append(m.partial, m.nodes[node].placement)
+		for sibling := m.nodes[node].right; sibling != node; sibling = m.nodes[sibling].right {
+			m.cover(m.nodes[sibling].column)
+		}
+		found += m.search(limit - found)
+		for sibling := m.nodes[node].left; sibling != node; sibling = m.nodes[sibling].left {
+			m.uncover(m.nodes[sibling].column)
+		}
+		m.partial = m.partial[:len(m.partial)-1]
+		if limit > 0 && found >= limit {
+			break
+		}
+	}
+	m.uncover(column)
+	return found
+}
+
+// cover removes the column and every row which satisfies it from the matrix.
+func (m *dlxMatrix) cover(column int) {
+	m.nodes[m.nodes[column].right].left = m.nodes[column].left
+	m.nodes[m.nodes[column].left].right = m.nodes[column].right
+	for node := m.nodes[column].down; node != column; node = m.nodes[node].down {
+		for sibling := m.nodes[node].right; sibling != node; sibling = m.nodes[sibling].right {
+			m.nodes[m.nodes[sibling].down].up = m.nodes[sibling].up
+			m.nodes[m.nodes[sibling].up].down = m.nodes[sibling].down
+			m.sizes[m.nodes[sibling].column]--
+		}
+	}
+}
+
+// uncover restores a column previously removed by cover, in exactly reverse order.
+func (m *dlxMatrix) uncover(column int) {
+	for node := m.nodes[column].up; node != column; node = m.nodes[node].up {
+		for sibling := m.nodes[node].left; sibling != node; sibling = m.nodes[sibling].left {
+			m.sizes[m.nodes[sibling].column]++
+			m.nodes[m.nodes[sibling].down].up = sibling
+			m.nodes[m.nodes[sibling].up].down = sibling
+		}
+	}
+	m.nodes[m.nodes[column].right].left = column
+	m.nodes[m.nodes[column].left].right = column
+}
@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDancingLinks(t *testing.T) {
+	assert.NotNil(t, NewDancingLinks())
+}
+
+func TestDancingLinks_Solve(t *testing.T) {
+
+	// Define The TestCases
+	testCases := map[string]struct {
+		grid     *Grid
+		solution *Grid
+	}{
+		"Hard":    {grid: testGrid(), solution: testGridFromString(testSolvedPuzzle)},
+		"Extreme": {grid: testGridFromString(testExtremePuzzle), solution: testGridFromString(testExtremeSolution)},
+		"Solved":  {grid: testGridFromString(testSolvedPuzzle), solution: testGridFromString(testSolvedPuzzle)},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			assert.True(t, NewDancingLinks().Solve(testCase.grid))
+			assert.Equal(t, testCase.solution, testCase.grid)
+		})
+	}
+}
+
+func TestDancingLinks_Solve_NoSolution(t *testing.T) {
+
+	// Create a Grid where a value has no home in the second Row
+	grid := testGrid()
+	for col := 0; col < 9; col++ {
+		grid.GetCell(1, col).EliminateValue(9)
+	}
+
+	// Verify that no solution is found and the Grid is left untouched
+	assert.False(t, NewDancingLinks().Solve(grid))
+	assert.Equal(t, 0, grid.GetCell(1, 0).GetValue())
+}
+
+func TestDlxMatrix_CoverUncover(t *testing.T) {
+	matrix := newDlxMatrix(testGrid())
+	nodes := append([]dlxNode{}, matrix.nodes...)
+	sizes := append([]int{}, matrix.sizes...)
+	matrix.cover(1)
+	assert.NotEqual(t, nodes, matrix.nodes)
+	matrix.uncover(1)
+	assert.Equal(t, nodes, matrix.nodes)
+	assert.Equal(t, sizes, matrix.sizes)
+}
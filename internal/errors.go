@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsolvable is matched (via errors.Is) by every UnsolvableError.
+var ErrUnsolvable = errors.New("sudoku is unsolvable")
+
+// UnsolvableError reports a contradiction which prevents a Grid from being solved,
+// naming the offending Cell or House.
+type UnsolvableError struct {
+	Position *Position // The Cell without possible values, if applicable
+	House    *House    // The House with a missing or repeated value, if applicable
+	Value    int       // The missing or repeated value, if applicable
+	Reason   string    // Description of the contradiction
+}
+
+// Error returns the reason for the contradiction, e.g. "r4c7 has no candidates".
+func (e *UnsolvableError) Error() string {
+	return e.Reason
+}
+
+// Unwrap allows errors.Is(err, ErrUnsolvable) to match any UnsolvableError.
+func (e *UnsolvableError) Unwrap() error {
+	return ErrUnsolvable
+}
+
+// noCandidatesError returns an UnsolvableError for an unknown Cell without any
+// possible values remaining.
+func noCandidatesError(position Position) *UnsolvableError {
+	return &UnsolvableError{
+		Position: &position,
+		Reason:   fmt.Sprintf("%s has no candidates", position),
+	}
+}
+
+// repeatedValueError returns an UnsolvableError for a value set more than once
+// in the same House.
+func repeatedValueError(house House, value int) *UnsolvableError {
+	return &UnsolvableError{
+		House:  &house,
+		Value:  value,
+		Reason: fmt.Sprintf("digit %d appears twice in %s", value, house),
+	}
+}
+
+// missingValueError returns an UnsolvableError for a value which is neither set
+// nor possible anywhere in a House.
+func missingValueError(house House, value int) *UnsolvableError {
+	return &UnsolvableError{
+		House:  &house,
+		Value:  value,
+		Reason: fmt.Sprintf("digit %d has no place in %s", value, house),
+	}
+}
+
+// exhaustedGuessesError returns an UnsolvableError for an unknown Cell where every
+// possible value was guessed and each led to a contradiction.
+func exhaustedGuessesError(position Position) *UnsolvableError {
+	return &UnsolvableError{
+		Position: &position,
+		Reason:   fmt.Sprintf("every candidate for %s leads to a contradiction", position),
+	}
+}
@@ -0,0 +1,33 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsolvableError(t *testing.T) {
+
+	// Define The TestCases
+	testCases := map[string]struct {
+		err       *UnsolvableError
+		expectErr string
+	}{
+		"No Candidates":     {err: noCandidatesError(Position{Row: 3, Col: 6}), expectErr: "r4c7 has no candidates"},
+		"Repeated Value":    {err: repeatedValueError(House{Kind: GroupHouse, Index: 1}, 5), expectErr: "digit 5 appears twice in box 2"},
+		"Missing Value":     {err: missingValueError(House{Kind: ColHouse, Index: 0}, 3), expectErr: "digit 3 has no place in column 1"},
+		"Exhausted Guesses": {err: exhaustedGuessesError(Position{Row: 0, Col: 0}), expectErr: "every candidate for r1c1 leads to a contradiction"},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			var err error = testCase.err
+			assert.EqualError(t, err, testCase.expectErr)
+			assert.ErrorIs(t, err, ErrUnsolvable)
+			unsolvableErr := &UnsolvableError{}
+			assert.True(t, errors.As(err, &unsolvableErr))
+		})
+	}
+}
@@ -52,6 +52,20 @@ func NewGridFromCsv(csvFile string) (*Grid, error) {
 	return grid, nil
 }
 
+// Copy returns a deep copy of the Grid such that speculative updates can be made
+// to the copy without affecting the original.
+func (g *Grid) Copy() *Grid {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	cells := [9][9]*Cell{}
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			cells[row][col] = g.cells[row][col].Copy()
+		}
+	}
+	return &Grid{cells: cells}
+}
+
 // String returns a "box-drawing" string representing the current state of the
 // Grid suitable for display.
 func (g *Grid) String() string {
@@ -117,6 +131,95 @@ func (g *Grid) SetValue(row int, col int, value int) {
 	g.eliminateValueFromGroup(row, col, value)
 }
 
+// IsSolved returns whether every Cell in the Grid has a known value.
+func (g *Grid) IsSolved() bool {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if g.cells[row][col].GetValue() == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Validate returns an UnsolvableError if the Grid can no longer be solved because
+// an unknown Cell has no possible values remaining, or a value is set more than
+// once in a Row, Column, or Group, or a value is neither set nor possible
+// anywhere in a Row, Column, or Group.  Returns nil if no contradiction is found.
+func (g *Grid) Validate() error {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	// Every unknown Cell must still have at least one possible value
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			cell := g.cells[row][col]
+			if cell.GetValue() == 0 && len(cell.GetPossibleValues()) == 0 {
+				return noCandidatesError(Position{Row: row, Col: col})
+			}
+		}
+	}
+
+	// Every value must be set at most once, and have a home, in each House
+	for _, house := range allHouses {
+		for value := 1; value <= 9; value++ {
+			set, possible := 0, 0
+			for _, position := range house.Positions() {
+				cell := g.cells[position.Row][position.Col]
+				if cell.GetValue() == value {
+					set = set + 1
+				} else if cell.IsPossibleValue(value) {
+					possible = possible + 1
+				}
+			}
+			if set > 1 {
+				return repeatedValueError(house, value)
+			}
+			if set == 0 && possible == 0 {
+				return missingValueError(house, value)
+			}
+		}
+	}
+	return nil
+}
+
+// fewestPossibleValuesCell returns the row/col of the unknown Cell with the
+// fewest possible values remaining, or -1,-1 if every Cell is known.
+func (g *Grid) fewestPossibleValuesCell() (int, int) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	bestRow, bestCol, bestCount := -1, -1, 10
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			cell := g.cells[row][col]
+			if cell.GetValue() != 0 {
+				continue
+			}
+			count := len(cell.GetPossibleValues())
+			if count < bestCount {
+				bestRow, bestCol, bestCount = row, col, count
+			}
+		}
+	}
+	return bestRow, bestCol
+}
+
+// setValuesFrom sets every Cell that is unknown in this Grid but known in the
+// other Grid, such as when adopting the result of a successful guess.
+func (g *Grid) setValuesFrom(other *Grid) {
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			value := other.GetCell(row, col).GetValue()
+			if value != 0 && g.GetCell(row, col).GetValue() == 0 {
+				g.SetValue(row, col, value)
+			}
+		}
+	}
+}
+
 func (g *Grid) eliminateValueFromRow(row int, value int) {
 	for col := 0; col < 9; col++ {
 		g.cells[row][col].EliminateValue(value)
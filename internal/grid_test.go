@@ -113,6 +113,114 @@ func TestGrid_SetValue(t *testing.T) {
 	}
 }
 
+func TestGrid_Copy(t *testing.T) {
+	grid := testGrid()
+	gridCopy := grid.Copy()
+	assert.Equal(t, grid, gridCopy)
+	gridCopy.SetValue(3, 5, 1)
+	assert.Equal(t, 1, gridCopy.GetCell(3, 5).GetValue())
+	assert.Equal(t, 0, grid.GetCell(3, 5).GetValue()) // Original is unaffected
+	assert.True(t, grid.GetCell(3, 4).IsPossibleValue(1))
+}
+
+func TestGrid_IsSolved(t *testing.T) {
+	assert.False(t, testGrid().IsSolved())
+	assert.True(t, testGridFromString(testSolvedPuzzle).IsSolved())
+}
+
+func TestGrid_Validate(t *testing.T) {
+
+	// A fresh puzzle or solved puzzle is valid
+	assert.Nil(t, testGrid().Validate())
+	assert.Nil(t, testGridFromString(testSolvedPuzzle).Validate())
+
+	// A Cell without any possible values is a contradiction
+	grid := testGrid()
+	for _, value := range grid.GetCell(0, 8).GetPossibleValues() {
+		grid.GetCell(0, 8).EliminateValue(value)
+	}
+	err := grid.Validate()
+	assert.ErrorIs(t, err, ErrUnsolvable)
+	assert.EqualError(t, err, "r1c9 has no candidates")
+
+	// A value without a home in a Row is a contradiction
+	grid = testGrid()
+	for col := 0; col < 9; col++ {
+		grid.GetCell(1, col).EliminateValue(9)
+	}
+	assert.EqualError(t, grid.Validate(), "digit 9 has no place in row 2")
+
+	// A value set twice in a Group is a contradiction
+	grid = testGrid()
+	grid.GetCell(1, 1).SetValue(2)
+	assert.EqualError(t, grid.Validate(), "digit 2 appears twice in box 1")
+}
+
+func TestGrid_fewestPossibleValuesCell(t *testing.T) {
+	grid := testGrid()
+	row, col := grid.fewestPossibleValuesCell()
+	assert.Equal(t, 0, grid.GetCell(row, col).GetValue())
+	fewest := len(grid.GetCell(row, col).GetPossibleValues())
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if grid.GetCell(row, col).GetValue() == 0 {
+				assert.GreaterOrEqual(t, len(grid.GetCell(row, col).GetPossibleValues()), fewest)
+			}
+		}
+	}
+	row, col = testGridFromString(testSolvedPuzzle).fewestPossibleValuesCell()
+	assert.Equal(t, -1, row)
+	assert.Equal(t, -1, col)
+}
+
+// testSolvedPuzzle is the solution to the samples/hard.csv puzzle.
+const testSolvedPuzzle = "" +
+	"269154378" +
+	"473289561" +
+	"581637429" +
+	"694571832" +
+	"812963745" +
+	"357842196" +
+	"728495613" +
+	"135726984" +
+	"946318257"
+
+// testExtremePuzzle is an "extreme" puzzle that requires guessing to solve.
+const testExtremePuzzle = "" +
+	"8........" +
+	"..36....." +
+	".7..9.2.." +
+	".5...7..." +
+	"....457.." +
+	"...1...3." +
+	"..1....68" +
+	"..85...1." +
+	".9....4.."
+
+// testExtremeSolution is the solution to testExtremePuzzle.
+const testExtremeSolution = "" +
+	"812753649" +
+	"943682175" +
+	"675491283" +
+	"154237896" +
+	"369845721" +
+	"287169534" +
+	"521974368" +
+	"438526917" +
+	"796318452"
+
+// testGridFromString returns a Grid initialized from an 81 character string
+// of the values in row order where '.' indicates an unknown value.
+func testGridFromString(puzzle string) *Grid {
+	grid := NewGrid()
+	for index, char := range puzzle {
+		if char >= '1' && char <= '9' {
+			grid.SetValue(index/9, index%9, int(char-'0'))
+		}
+	}
+	return grid
+}
+
 // testGrid returns a sample grid version of the
 // samples/hard.csv puzzle for testing ; )
 func testGrid() *Grid {
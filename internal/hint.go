@@ -0,0 +1,85 @@
+package internal
+
+import "fmt"
+
+// HintLevel controls how much of the next Step a Hint reveals, from a nudge
+// towards a House up to the full deduction.
+type HintLevel int
+
+const (
+	HouseHint      HintLevel = iota + 1 // Which House to look at
+	TechniqueHint                       // The technique to apply there
+	CellsHint                           // The exact Cells involved
+	ConclusionHint                      // The placements and eliminations
+)
+
+// IsValid returns whether the HintLevel is one of the defined levels (1-4).
+func (l HintLevel) IsValid() bool {
+	return l >= HouseHint && l <= ConclusionHint
+}
+
+// Hint is a nudge towards the next Step, revealing progressively more of it as
+// the level increases.
+type Hint struct {
+	Level HintLevel // How much of the Step to reveal
+	Step  Step      // The next Step, in full
+}
+
+// House returns the House to look at for the Step, i.e. the first of its Houses,
+// otherwise the first House holding all of its Cells, otherwise the Group of its
+// first conclusion or, failing that, of its first Cell.  Returns false if the Step
+// has none of them.
+func (h Hint) House() (House, bool) {
+	if len(h.Step.Houses) > 0 {
+		return h.Step.Houses[0], true
+	}
+	for _, house := range allHouses {
+		holdsAll := len(h.Step.Cells) > 0
+		for _, position := range h.Step.Cells {
+			holdsAll = holdsAll && house.contains(position)
+		}
+		if holdsAll {
+			return house, true
+		}
+	}
+	conclusions := append(append([]Candidate{}, h.Step.Placements...), h.Step.Eliminations...)
+	switch {
+	case len(conclusions) > 0:
+		return boxOf(conclusions[0].Position), true
+	case len(h.Step.Cells) > 0:
+		return boxOf(h.Step.Cells[0]), true
+	default:
+		return House{}, false
+	}
+}
+
+// String returns the Hint revealing as much of the Step as its level allows, e.g.
+// "Look at row 4 and cells r4c1,r4c5, where Naked Pair makes progress".  A Step
+// without any House to look at points to the whole grid.
+func (h Hint) String() string {
+	place := "the grid"
+	if house, found := h.House(); found {
+		place = house.String()
+	}
+	switch {
+	case h.Level >= ConclusionHint:
+		return h.Step.String()
+	case h.Level == CellsHint:
+		return fmt.Sprintf("Look at %s and cells %s, where %s makes progress", place, formatPositions(h.Step.Cells), h.Step.Technique)
+	case h.Level == TechniqueHint:
+		return fmt.Sprintf("Look at %s, where %s makes progress", place, h.Step.Technique)
+	default:
+		return fmt.Sprintf("Look at %s", place)
+	}
+}
+
+// Hint returns a Hint at the specified level for the easiest deduction which can
+// be made in the Grid, without updating the Grid.  Returns false if there is no
+// deduction to hint at.
+func (s *Solver) Hint(grid *Grid, level HintLevel) (Hint, bool) {
+	step, found := s.NextStep(grid)
+	if !found {
+		return Hint{}, false
+	}
+	return Hint{Level: level, Step: step}, true
+}
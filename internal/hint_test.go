@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHintLevel_IsValid(t *testing.T) {
+	assert.False(t, HintLevel(0).IsValid())
+	assert.True(t, HouseHint.IsValid())
+	assert.True(t, ConclusionHint.IsValid())
+	assert.False(t, HintLevel(5).IsValid())
+}
+
+func TestHint_String(t *testing.T) {
+	step := Step{
+		Technique:    TechniqueNakedPair,
+		Eliminations: []Candidate{newCandidate(3, 6, 5)},
+		Cells:        []Position{{3, 0}, {3, 4}},
+		Houses:       []House{{Kind: RowHouse, Index: 3}},
+		Reason:       "Values {5,8} are confined to cells r4c1,r4c5 in row 4",
+	}
+
+	// Define The TestCases
+	testCases := map[string]struct {
+		level  HintLevel
+		expect string
+	}{
+		"House":      {level: HouseHint, expect: "Look at row 4"},
+		"Technique":  {level: TechniqueHint, expect: "Look at row 4, where Naked Pair makes progress"},
+		"Cells":      {level: CellsHint, expect: "Look at row 4 and cells r4c1,r4c5, where Naked Pair makes progress"},
+		"Conclusion": {level: ConclusionHint, expect: "Naked Pair: r4c7<>5 (Values {5,8} are confined to cells r4c1,r4c5 in row 4)"},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			assert.Equal(t, testCase.expect, Hint{Level: testCase.level, Step: step}.String())
+		})
+	}
+}
+
+func TestHint_House(t *testing.T) {
+
+	// Cells sharing a House without any Houses named
+	hint := Hint{Step: Step{Cells: []Position{{0, 0}, {2, 2}}, Eliminations: []Candidate{newCandidate(1, 1, 3)}}}
+	house, found := hint.House()
+	assert.True(t, found)
+	assert.Equal(t, House{Kind: GroupHouse, Index: 0}, house)
+
+	// Cells spread across Houses fall back to the Group of the first conclusion
+	hint = Hint{Step: Step{Cells: []Position{{0, 0}, {8, 8}}, Eliminations: []Candidate{newCandidate(4, 4, 3)}}}
+	house, found = hint.House()
+	assert.True(t, found)
+	assert.Equal(t, House{Kind: GroupHouse, Index: 4}, house)
+
+	// A placement alone gives its Group
+	hint = Hint{Step: Step{Placements: []Candidate{newCandidate(8, 8, 1)}}}
+	house, found = hint.House()
+	assert.True(t, found)
+	assert.Equal(t, House{Kind: GroupHouse, Index: 8}, house)
+
+	// A Step without Houses, Cells or conclusions has no House to look at
+	hint = Hint{Level: TechniqueHint, Step: Step{Technique: TechniqueGuess}}
+	_, found = hint.House()
+	assert.False(t, found)
+	assert.Equal(t, "Look at the grid, where Guess makes progress", hint.String())
+}
+
+func TestSolver_Hint(t *testing.T) {
+	solver := NewSolver(10, false)
+
+	// Verify the hint is for the next Step, leaving the Grid untouched
+	grid := testGrid()
+	hint, found := solver.Hint(grid, TechniqueHint)
+	assert.True(t, found)
+	assert.Equal(t, TechniqueHint, hint.Level)
+	assert.Equal(t, TechniqueNakedSingle, hint.Step.Technique)
+	assert.Equal(t, testGrid(), grid)
+
+	// Verify there is no hint for a solved Grid
+	_, found = solver.Hint(testGridFromString(testSolvedPuzzle), HouseHint)
+	assert.False(t, found)
+}
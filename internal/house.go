@@ -0,0 +1,133 @@
+package internal
+
+import "fmt"
+
+// Position identifies a single Cell on the Sudoku board by its zero-based
+// row/col coordinates.
+type Position struct {
+	Row int
+	Col int
+}
+
+// String returns the Position in the standard one-based "rNcM" notation.
+func (p Position) String() string {
+	return fmt.Sprintf("r%dc%d", p.Row+1, p.Col+1)
+}
+
+// HouseKind distinguishes the three kinds of House on the Sudoku board.
+type HouseKind int
+
+const (
+	RowHouse   HouseKind = iota // One of the 9 Rows
+	ColHouse                    // One of the 9 Columns
+	GroupHouse                  // One of the 9 (sub) Groups, also known as boxes
+)
+
+// House is a Row, Column, or Group of 9 Cells which must hold each value exactly once.
+type House struct {
+	Kind  HouseKind
+	Index int // Zero-based index, with Groups numbered left to right, top to bottom
+}
+
+// allHouses lists the 27 Houses in Row, Column, then Group order.
+var allHouses = func() [27]House {
+	houses := [27]House{}
+	for index := 0; index < 9; index++ {
+		houses[index] = House{Kind: RowHouse, Index: index}
+		houses[index+9] = House{Kind: ColHouse, Index: index}
+		houses[index+18] = House{Kind: GroupHouse, Index: index}
+	}
+	return houses
+}()
+
+// String returns the House in one-based human readable form such as "row 4".
+func (h House) String() string {
+	switch h.Kind {
+	case RowHouse:
+		return fmt.Sprintf("row %d", h.Index+1)
+	case ColHouse:
+		return fmt.Sprintf("column %d", h.Index+1)
+	default:
+		return fmt.Sprintf("box %d", h.Index+1)
+	}
+}
+
+// Positions returns the Positions of the 9 Cells in the House.
+func (h House) Positions() [9]Position {
+	positions := [9]Position{}
+	for offset := 0; offset < 9; offset++ {
+		switch h.Kind {
+		case RowHouse:
+			positions[offset] = Position{Row: h.Index, Col: offset}
+		case ColHouse:
+			positions[offset] = Position{Row: offset, Col: h.Index}
+		default:
+			positions[offset] = Position{Row: (h.Index/3)*3 + offset/3, Col: (h.Index%3)*3 + offset%3}
+		}
+	}
+	return positions
+}
+
+// contains returns whether the Cell at the Position belongs to the House.
+func (h House) contains(position Position) bool {
+	switch h.Kind {
+	case RowHouse:
+		return position.Row == h.Index
+	case ColHouse:
+		return position.Col == h.Index
+	default:
+		return (position.Row/3)*3+position.Col/3 == h.Index
+	}
+}
+
+// intersection is the 3 Cells shared by a Group and a Row or Column (line).
+type intersection struct {
+	group     House
+	line      House
+	positions [3]Position
+}
+
+// allIntersections lists the 54 Group/line intersections, in Group order with
+// Rows before Columns.
+var allIntersections = func() []intersection {
+	intersections := []intersection{}
+	for index := 0; index < 9; index++ {
+		group := House{Kind: GroupHouse, Index: index}
+		groupRow := (index / 3) * 3
+		groupCol := (index % 3) * 3
+		for offset := 0; offset < 3; offset++ {
+			row := intersection{group: group, line: House{Kind: RowHouse, Index: groupRow + offset}}
+			col := intersection{group: group, line: House{Kind: ColHouse, Index: groupCol + offset}}
+			for cell := 0; cell < 3; cell++ {
+				row.positions[cell] = Position{Row: groupRow + offset, Col: groupCol + cell}
+				col.positions[cell] = Position{Row: groupRow + cell, Col: groupCol + offset}
+			}
+			intersections = append(intersections, row, col)
+		}
+	}
+	return intersections
+}()
+
+// sees returns whether the Cells at two different Positions share a Row,
+// Column, or Group, and so cannot hold the same value.
+func (p Position) sees(other Position) bool {
+	if p == other {
+		return false
+	}
+	return p.Row == other.Row || p.Col == other.Col || (p.Row/3 == other.Row/3 && p.Col/3 == other.Col/3)
+}
+
+// peers returns the Positions of the 20 other Cells which the Cell at the
+// Position sees.
+func (p Position) peers() []Position {
+	peers := make([]Position, 0, 20)
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			other := Position{Row: row, Col: col}
+			if p.sees(other) {
+				peers = append(peers, other)
+			}
+		}
+	}
+	return peers
+}
@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPosition_String(t *testing.T) {
+	assert.Equal(t, "r1c1", Position{Row: 0, Col: 0}.String())
+	assert.Equal(t, "r4c7", Position{Row: 3, Col: 6}.String())
+}
+
+func TestHouse_String(t *testing.T) {
+	assert.Equal(t, "row 4", House{Kind: RowHouse, Index: 3}.String())
+	assert.Equal(t, "column 7", House{Kind: ColHouse, Index: 6}.String())
+	assert.Equal(t, "box 2", House{Kind: GroupHouse, Index: 1}.String())
+}
+
+func TestHouse_Positions(t *testing.T) {
+	row := House{Kind: RowHouse, Index: 3}.Positions()
+	assert.Equal(t, Position{Row: 3, Col: 0}, row[0])
+	assert.Equal(t, Position{Row: 3, Col: 8}, row[8])
+	col := House{Kind: ColHouse, Index: 6}.Positions()
+	assert.Equal(t, Position{Row: 0, Col: 6}, col[0])
+	assert.Equal(t, Position{Row: 8, Col: 6}, col[8])
+	group := House{Kind: GroupHouse, Index: 5}.Positions()
+	assert.Equal(t, Position{Row: 3, Col: 6}, group[0])
+	assert.Equal(t, Position{Row: 4, Col: 7}, group[4])
+	assert.Equal(t, Position{Row: 5, Col: 8}, group[8])
+}
+
+func TestAllHouses(t *testing.T) {
+	assert.Equal(t, House{Kind: RowHouse, Index: 0}, allHouses[0])
+	assert.Equal(t, House{Kind: ColHouse, Index: 0}, allHouses[9])
+	assert.Equal(t, House{Kind: GroupHouse, Index: 8}, allHouses[26])
+}
+
+func TestHouse_contains(t *testing.T) {
+	position := Position{Row: 4, Col: 7}
+	assert.True(t, House{Kind: RowHouse, Index: 4}.contains(position))
+	assert.False(t, House{Kind: RowHouse, Index: 7}.contains(position))
+	assert.True(t, House{Kind: ColHouse, Index: 7}.contains(position))
+	assert.True(t, House{Kind: GroupHouse, Index: 5}.contains(position))
+	assert.False(t, House{Kind: GroupHouse, Index: 4}.contains(position))
+}
+
+func TestAllIntersections(t *testing.T) {
+	assert.Len(t, allIntersections, 54)
+	for _, intersection := range allIntersections {
+		for _, position := range intersection.positions {
+			assert.True(t, intersection.group.contains(position))
+			assert.True(t, intersection.line.contains(position))
+		}
+	}
+	assert.Equal(t, [3]Position{{Row: 3, Col: 7}, {Row: 4, Col: 7}, {Row: 5, Col: 7}}, allIntersections[5*6+3].positions)
+}
+
+func TestPosition_sees(t *testing.T) {
+	position := Position{Row: 4, Col: 4}
+	assert.True(t, position.sees(Position{Row: 4, Col: 0}))
+	assert.True(t, position.sees(Position{Row: 0, Col: 4}))
+	assert.True(t, position.sees(Position{Row: 3, Col: 5}))
+	assert.False(t, position.sees(Position{Row: 3, Col: 6}))
+	assert.False(t, position.sees(position))
+}
+
+func TestPosition_peers(t *testing.T) {
+	position := Position{Row: 4, Col: 4}
+	peers := position.peers()
+	assert.Len(t, peers, 20)
+	for _, peer := range peers {
+		assert.True(t, position.sees(peer))
+	}
+}
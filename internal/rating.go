@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+)
+
+// DefaultHardStepDifficulty is the difficulty at and above which a Step counts as
+// hard, i.e. anything beyond the basic subsets, fish and single value patterns.
+const DefaultHardStepDifficulty = 4.0
+
+// Rating describes the difficulty of a puzzle on the scale of the Strategy
+// difficulties, in the style of Sudoku Explainer or HoDoKu, where singles rate
+// around 1.2-2.3 and forcing chains 6.5 and up.
+type Rating struct {
+	Score     float64        // Overall rating, the hardest difficulty plus any weight for the hard Steps
+	Tier      DifficultyTier // Named tier of the Score
+	Hardest   float64        // Difficulty of the hardest Step needed
+	Technique string         // Technique of the hardest Step needed
+	HardSteps int            // Number of Steps at or above the hard Step difficulty
+	Steps     int            // Number of Steps in the solution path
+	Status    SolveStatus    // Outcome of the solve behind the rating
+	Method    SolveMethod    // Hardest kind of technique needed
+}
+
+// String returns the Rating in the form "4.6 expert (XY-Wing, 3 hard steps of 52, Pattern Logic)".
+func (r Rating) String() string {
+	rating := fmt.Sprintf("%.1f %s (%s, %d hard steps of %d, %s)", r.Score, r.Tier, r.Technique, r.HardSteps, r.Steps, r.Method)
+	if r.Status != Solved {
+		rating = fmt.Sprintf("%s %s", rating, r.Status)
+	}
+	return rating
+}
+
+// Rater rates puzzles by solving them with a Solver and scoring the hardest Step
+// of the solution path, optionally weighting the number of hard Steps so that
+// puzzles needing a hard technique repeatedly rate above those needing it once.
+type Rater struct {
+	solver             *Solver
+	hardStepDifficulty float64 // Difficulty at and above which a Step counts as hard
+	hardStepWeight     float64 // Extra score for each hard Step beyond the first
+	thresholds         TierThresholds
+}
+
+// NewRater returns a Rater using the Solver, with no extra weight for hard Steps
+// and the DefaultTierThresholds.
+func NewRater(solver *Solver) *Rater {
+	return &Rater{
+		solver:             solver,
+		hardStepDifficulty: DefaultHardStepDifficulty,
+		thresholds:         DefaultTierThresholds,
+	}
+}
+
+// SetHardStepDifficulty controls the difficulty at and above which a Step counts
+// as hard.
+func (r *Rater) SetHardStepDifficulty(difficulty float64) {
+	r.hardStepDifficulty = difficulty
+}
+
+// SetHardStepWeight controls the extra score added for each hard Step beyond the
+// first, e.g. 0.1 rates a puzzle needing 3 XY-Wings (4.2) as 4.4.  The default
+// of 0 rates a puzzle purely by its hardest Step.
+func (r *Rater) SetHardStepWeight(weight float64) {
+	r.hardStepWeight = weight
+}
+
+// SetTierThresholds controls the scores at which the Rating moves up a tier.
+func (r *Rater) SetTierThresholds(thresholds TierThresholds) {
+	r.thresholds = thresholds
+}
+
+// Rate returns the Rating of the puzzle, solving a copy so the Grid is left
+// untouched.  Returns an UnsolvableError if the puzzle has no solution.
+func (r *Rater) Rate(grid *Grid) (Rating, error) {
+
+	// Apply the easiest Strategy available at every point, as the Solver's order
+	// may favour speed over difficulty
+	solver := *r.solver
+	solver.registry = r.solver.registry.byDifficulty()
+	result, err := solver.Solve(grid.Copy())
+	if err != nil {
+		return Rating{Status: result.Status}, err
+	}
+	return r.rateSteps(result), nil
+}
+
+// rateSteps returns the Rating of the solution path of the SolveResult.
+func (r *Rater) rateSteps(result SolveResult) Rating {
+	rating := Rating{Steps: len(result.Steps), Status: result.Status, Method: result.Method()}
+	for _, step := range result.Steps {
+		if step.Difficulty > rating.Hardest {
+			rating.Hardest, rating.Technique = step.Difficulty, step.Technique
+		}
+		if step.Difficulty >= r.hardStepDifficulty {
+			rating.HardSteps++
+		}
+	}
+	rating.Score = rating.Hardest
+	if rating.HardSteps > 1 {
+		rating.Score += r.hardStepWeight * float64(rating.HardSteps-1)
+	}
+	rating.Score = math.Round(rating.Score*10) / 10
+	rating.Tier = r.thresholds.Tier(rating.Score)
+	return rating
+}
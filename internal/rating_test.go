@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRating_String(t *testing.T) {
+	rating := Rating{Score: 4.6, Tier: ExpertTier, Hardest: 4.2, Technique: TechniqueXYWing, HardSteps: 3, Steps: 52, Status: Solved, Method: PatternLogic}
+	assert.Equal(t, "4.6 expert (XY-Wing, 3 hard steps of 52, Pattern Logic)", rating.String())
+	rating.Status = Stalled
+	assert.Equal(t, "4.6 expert (XY-Wing, 3 hard steps of 52, Pattern Logic) Stalled", rating.String())
+}
+
+func TestRater_rateSteps(t *testing.T) {
+	result := newSolveResult()
+	result.Status = Solved
+	for _, step := range []Step{
+		{Technique: TechniqueHiddenSingleGroup, Difficulty: 1.2},
+		{Technique: TechniqueXYWing, Difficulty: 4.2},
+		{Technique: TechniqueXWing, Difficulty: 3.2},
+		{Technique: TechniqueXYWing, Difficulty: 4.2},
+		{Technique: TechniqueXChain, Difficulty: 4.7},
+	} {
+		result.addStep(step)
+	}
+
+	// Define The TestCases
+	testCases := map[string]struct {
+		weight     float64
+		difficulty float64
+		score      float64
+		hardSteps  int
+		thresholds TierThresholds
+		tier       DifficultyTier
+	}{
+		"Hardest Step":    {weight: 0, difficulty: DefaultHardStepDifficulty, score: 4.7, hardSteps: 3, thresholds: DefaultTierThresholds, tier: ExpertTier},
+		"Weighted Steps":  {weight: 0.1, difficulty: DefaultHardStepDifficulty, score: 4.9, hardSteps: 3, thresholds: DefaultTierThresholds, tier: ExpertTier},
+		"Low Threshold":   {weight: 0.1, difficulty: 3.0, score: 5.0, hardSteps: 4, thresholds: DefaultTierThresholds, tier: ExpertTier},
+		"Tier Thresholds": {weight: 0, difficulty: DefaultHardStepDifficulty, score: 4.7, hardSteps: 3, thresholds: TierThresholds{1.5, 2.0, 3.0, 4.5, 8.0}, tier: MasterTier},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			rater := NewRater(NewSolver(10, false))
+			rater.SetHardStepWeight(testCase.weight)
+			rater.SetHardStepDifficulty(testCase.difficulty)
+			rater.SetTierThresholds(testCase.thresholds)
+			rating := rater.rateSteps(*result)
+			assert.Equal(t, testCase.score, rating.Score)
+			assert.Equal(t, 4.7, rating.Hardest)
+			assert.Equal(t, TechniqueXChain, rating.Technique)
+			assert.Equal(t, testCase.hardSteps, rating.HardSteps)
+			assert.Equal(t, testCase.tier, rating.Tier)
+			assert.Equal(t, 5, rating.Steps)
+		})
+	}
+}
+
+func TestRater_Rate(t *testing.T) {
+
+	// Define The TestCases
+	testCases := map[string]struct {
+		grid      *Grid
+		score     float64
+		technique string
+		method    SolveMethod
+	}{
+		"Singles": {
+			grid:      testGrid(),
+			score:     1.2,
+			technique: TechniqueHiddenSingleGroup,
+			method:    PatternLogic,
+		},
+		"Forcing Chains": {
+			grid:      testGridFromString(".4.1.....9....8.4..67..45....3.65.....2....95..43......8..9.7.....5...13........4"),
+			score:     7.0,
+			technique: TechniqueNishio,
+			method:    ForcingChains,
+		},
+		"Guessing": {
+			grid:      testGridFromString(testExtremePuzzle),
+			score:     GuessDifficulty,
+			technique: TechniqueGuess,
+			method:    BruteForce,
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			original := testCase.grid.Copy()
+			rating, err := NewRater(NewSolver(200, false)).Rate(testCase.grid)
+			assert.Nil(t, err)
+			assert.Equal(t, Solved, rating.Status)
+			assert.Equal(t, testCase.score, rating.Score)
+			assert.Equal(t, testCase.technique, rating.Technique)
+			assert.Equal(t, testCase.method, rating.Method)
+			assert.Equal(t, original, testCase.grid)
+		})
+	}
+}
+
+func TestRater_Rate_Contradiction(t *testing.T) {
+	grid := testGrid()
+	for col := 0; col < 9; col++ {
+		grid.GetCell(1, col).EliminateValue(9)
+	}
+	rating, err := NewRater(NewSolver(10, false)).Rate(grid)
+	assert.ErrorIs(t, err, ErrUnsolvable)
+	assert.Equal(t, Contradiction, rating.Status)
+}
@@ -0,0 +1,141 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SolveStatus indicates the outcome of a call to Solver.Solve().
+type SolveStatus int
+
+const (
+	Solved         SolveStatus = iota // Every Cell in the Grid has a known value
+	Stalled                           // No technique could make further progress
+	Contradiction                     // The Grid was found to have no solution
+	BudgetExceeded                    // Max iterations reached before solving
+)
+
+// String returns a human readable name for the SolveStatus.
+func (s SolveStatus) String() string {
+	switch s {
+	case Solved:
+		return "Solved"
+	case Stalled:
+		return "Stalled"
+	case Contradiction:
+		return "Contradiction"
+	case BudgetExceeded:
+		return "BudgetExceeded"
+	default:
+		return fmt.Sprintf("SolveStatus(%d)", int(s))
+	}
+}
+
+// SolveMethod indicates the hardest kind of technique needed by a call to
+// Solver.Solve(), distinguishing a puzzle solved by recognizing patterns from one
+// which needed forcing chains or brute force guessing.
+type SolveMethod int
+
+const (
+	PatternLogic  SolveMethod = iota // Only pattern based techniques were needed
+	ForcingChains                    // Forcing chains or Nishio were needed, but no guessing
+	BruteForce                       // Guessing with backtracking was needed
+)
+
+// String returns a human readable name for the SolveMethod.
+func (m SolveMethod) String() string {
+	switch m {
+	case PatternLogic:
+		return "Pattern Logic"
+	case ForcingChains:
+		return "Forcing Chains"
+	case BruteForce:
+		return "Brute Force"
+	default:
+		return fmt.Sprintf("SolveMethod(%d)", int(m))
+	}
+}
+
+// SolveResult describes the outcome of a call to Solver.Solve().
+type SolveResult struct {
+	Status     SolveStatus    // Outcome of the solve
+	Iterations int            // Number of passes through the techniques
+	Elapsed    time.Duration  // Time taken to solve
+	Filled     int            // Number of Cells whose value was set
+	Placements map[string]int // Number of values set by each technique
+	Deductions map[string]int // Number of Steps made by each logical technique
+	Steps      []Step         // The solution path, i.e. every Step made in order, including guesses
+}
+
+// newSolveResult returns an empty SolveResult ready to accumulate placements.
+func newSolveResult() *SolveResult {
+	return &SolveResult{
+		Placements: map[string]int{},
+		Deductions: map[string]int{},
+	}
+}
+
+// addPlacements records the specified number of values set by a technique.
+func (r *SolveResult) addPlacements(technique string, count int) {
+	if count > 0 {
+		r.Placements[technique] = r.Placements[technique] + count
+		r.Filled = r.Filled + count
+	}
+}
+
+// addStep records a Step made by a logical technique, along with its placements.
+func (r *SolveResult) addStep(step Step) {
+	r.Deductions[step.Technique] = r.Deductions[step.Technique] + 1
+	r.addPlacements(step.Technique, len(step.Placements))
+	r.Steps = append(r.Steps, step)
+}
+
+// addGuess records a Step placing a guessed value, which is not a deduction.
+func (r *SolveResult) addGuess(step Step) {
+	r.addPlacements(TechniqueGuess, len(step.Placements))
+	r.Steps = append(r.Steps, step)
+}
+
+// merge accumulates the iterations, placements, and Steps of another SolveResult,
+// whose Steps follow those already made.
+func (r *SolveResult) merge(other *SolveResult) {
+	r.Iterations = r.Iterations + other.Iterations
+	r.Steps = append(r.Steps, other.Steps...)
+	for technique, count := range other.Placements {
+		r.addPlacements(technique, count)
+	}
+	for technique, count := range other.Deductions {
+		r.Deductions[technique] = r.Deductions[technique] + count
+	}
+}
+
+// Method returns the hardest kind of technique used, i.e. BruteForce if any value
+// was guessed, otherwise ForcingChains if any Step was made by a forcing technique.
+func (r SolveResult) Method() SolveMethod {
+	if r.Placements[TechniqueGuess] > 0 {
+		return BruteForce
+	}
+	for technique, count := range r.Deductions {
+		if count > 0 && forcingTechniques[technique] {
+			return ForcingChains
+		}
+	}
+	return PatternLogic
+}
+
+// String returns a single line summary of the SolveResult suitable for logging.
+func (r SolveResult) String() string {
+	techniques := make([]string, 0, len(r.Placements))
+	for technique := range r.Placements {
+		techniques = append(techniques, technique)
+	}
+	sort.Strings(techniques)
+	placements := make([]string, len(techniques))
+	for index, technique := range techniques {
+		placements[index] = fmt.Sprintf("%s=%d", technique, r.Placements[technique])
+	}
+	return fmt.Sprintf("%s in %d iterations over %s, filled %d cells [%s]",
+		r.Status, r.Iterations, r.Elapsed, r.Filled, strings.Join(placements, ", "))
+}
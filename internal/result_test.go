@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolveStatus_String(t *testing.T) {
+	assert.Equal(t, "Solved", Solved.String())
+	assert.Equal(t, "Stalled", Stalled.String())
+	assert.Equal(t, "Contradiction", Contradiction.String())
+	assert.Equal(t, "BudgetExceeded", BudgetExceeded.String())
+	assert.Equal(t, "SolveStatus(99)", SolveStatus(99).String())
+}
+
+func TestSolveMethod_String(t *testing.T) {
+	assert.Equal(t, "Pattern Logic", PatternLogic.String())
+	assert.Equal(t, "Forcing Chains", ForcingChains.String())
+	assert.Equal(t, "Brute Force", BruteForce.String())
+	assert.Equal(t, "SolveMethod(99)", SolveMethod(99).String())
+}
+
+func TestSolveResult_addPlacements(t *testing.T) {
+	result := newSolveResult()
+	result.addPlacements(TechniqueNakedSingle, 3)
+	result.addPlacements(TechniqueNakedSingle, 2)
+	result.addPlacements(TechniqueGuess, 0)
+	assert.Equal(t, map[string]int{TechniqueNakedSingle: 5}, result.Placements)
+	assert.Equal(t, 5, result.Filled)
+}
+
+func TestSolveResult_addStep(t *testing.T) {
+	result := newSolveResult()
+	result.addStep(Step{Technique: TechniqueNakedSingle, Placements: []Candidate{newCandidate(0, 0, 1)}})
+	result.addStep(Step{Technique: TechniqueXWing, Eliminations: []Candidate{newCandidate(0, 1, 2)}})
+	assert.Equal(t, map[string]int{TechniqueNakedSingle: 1}, result.Placements)
+	assert.Equal(t, map[string]int{TechniqueNakedSingle: 1, TechniqueXWing: 1}, result.Deductions)
+	assert.Equal(t, 1, result.Filled)
+	assert.Equal(t, []string{TechniqueNakedSingle, TechniqueXWing}, []string{result.Steps[0].Technique, result.Steps[1].Technique})
+}
+
+func TestSolveResult_addGuess(t *testing.T) {
+	result := newSolveResult()
+	result.addGuess(Step{Technique: TechniqueGuess, Placements: []Candidate{newCandidate(0, 0, 1)}})
+	assert.Equal(t, map[string]int{TechniqueGuess: 1}, result.Placements)
+	assert.Empty(t, result.Deductions)
+	assert.Len(t, result.Steps, 1)
+}
+
+func TestSolveResult_Method(t *testing.T) {
+	result := newSolveResult()
+	result.addStep(Step{Technique: TechniqueXWing})
+	assert.Equal(t, PatternLogic, result.Method())
+	result.addStep(Step{Technique: TechniqueNishio})
+	assert.Equal(t, ForcingChains, result.Method())
+	result.addPlacements(TechniqueGuess, 1)
+	assert.Equal(t, BruteForce, result.Method())
+}
+
+func TestSolveResult_merge(t *testing.T) {
+	result := newSolveResult()
+	result.Iterations = 2
+	result.addPlacements(TechniqueNakedSingle, 3)
+	other := newSolveResult()
+	other.Iterations = 4
+	other.addStep(Step{Technique: TechniqueNakedSingle, Placements: []Candidate{newCandidate(0, 0, 1)}})
+	other.addPlacements(TechniqueGuess, 1)
+	result.merge(other)
+	assert.Equal(t, 6, result.Iterations)
+	assert.Equal(t, 5, result.Filled)
+	assert.Equal(t, map[string]int{TechniqueNakedSingle: 4, TechniqueGuess: 1}, result.Placements)
+	assert.Equal(t, map[string]int{TechniqueNakedSingle: 1}, result.Deductions)
+	assert.Len(t, result.Steps, 1)
+}
+
+func TestSolveResult_String(t *testing.T) {
+	result := newSolveResult()
+	result.Status = Solved
+	result.Iterations = 3
+	result.Elapsed = 2 * time.Millisecond
+	result.addPlacements(TechniqueNakedSingle, 4)
+	result.addPlacements(TechniqueGuess, 1)
+	assert.Equal(t, "Solved in 3 iterations over 2ms, filled 5 cells [Guess=1, Naked Single=4]", result.String())
+}
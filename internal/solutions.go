@@ -0,0 +1,15 @@
+package internal
+
+// CountSolutions returns the number of solutions the specified Grid has, without
+// modifying it.  The search stops early once the limit is reached, so a limit of
+// 2 is sufficient to distinguish between no solution, a unique solution, and
+// multiple solutions.  A limit of zero or less counts every solution.
+func CountSolutions(grid *Grid, limit int) int {
+	return newDlxMatrix(grid).search(limit)
+}
+
+// HasUniqueSolution returns whether the specified Grid has exactly one solution,
+// as expected of a proper Sudoku puzzle.
+func HasUniqueSolution(grid *Grid) bool {
+	return CountSolutions(grid, 2) == 1
+}
@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountSolutions(t *testing.T) {
+
+	// Create a Grid with two solutions by removing an interchangeable pair of
+	// values (a "deadly pattern") from a solved Grid
+	multiple := []rune(testSolvedPuzzle)
+	for _, index := range []int{1, 7, 10, 16} {
+		multiple[index] = '.'
+	}
+
+	// Create a Grid with no solution where a value has no home in the second Row
+	none := testGrid()
+	for col := 0; col < 9; col++ {
+		none.GetCell(1, col).EliminateValue(9)
+	}
+
+	// Define The TestCases
+	testCases := map[string]struct {
+		grid   *Grid
+		limit  int
+		expect int
+		unique bool
+	}{
+		"Unique":          {grid: testGrid(), limit: 2, expect: 1, unique: true},
+		"Solved":          {grid: testGridFromString(testSolvedPuzzle), limit: 2, expect: 1, unique: true},
+		"No Solution":     {grid: none, limit: 2, expect: 0, unique: false},
+		"Multiple":        {grid: testGridFromString(string(multiple)), limit: 0, expect: 2, unique: false},
+		"Empty Limited":   {grid: NewGrid(), limit: 2, expect: 2, unique: false},
+		"Empty Limited 5": {grid: NewGrid(), limit: 5, expect: 5, unique: false},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			before := testCase.grid.Copy()
+			assert.Equal(t, testCase.expect, CountSolutions(testCase.grid, testCase.limit))
+			assert.Equal(t, testCase.unique, HasUniqueSolution(testCase.grid))
+			assert.Equal(t, before, testCase.grid) // Grid is not modified
+		})
+	}
+}
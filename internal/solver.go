@@ -7,242 +7,264 @@ import (
 
 const MaxIterations = 100 // Maximum number of passes through the algorithm
 
+// Names of the built-in techniques used to set values, as reported in a SolveResult.
+const (
+	TechniqueNakedSingle       = "Naked Single"           // Only one possible value remaining for a Cell
+	TechniqueHiddenSingleRow   = "Hidden Single (Row)"    // Only Cell in the Row with a possible value
+	TechniqueHiddenSingleCol   = "Hidden Single (Column)" // Only Cell in the Column with a possible value
+	TechniqueHiddenSingleGroup = "Hidden Single (Group)"  // Only Cell in the Group with a possible value
+	TechniqueGuess             = "Guess"                  // Best guess when no logical technique applies
+)
+
+// GuessDifficulty is the difficulty of a guess, harder than any logical technique.
+const GuessDifficulty = 10.0
+
 // Solver contains the basic state used when solving a Grid.
 type Solver struct {
 	maxIterations int
 	verbose       bool
+	guessing      bool         // Whether to fall back to guessing when the Strategies stall
+	assumeUnique  bool         // Whether to apply Strategies which rely on a unique solution
+	tier          StrategyTier // The highest tier of Strategies to apply
+	registry      *Registry    // The Strategies to apply
 }
 
-// NewSolver returns a Solver with the specified configuration.
+// NewSolver returns a Solver with the specified configuration, using all of
+// the built-in Strategies and falling back to guessing when they stall.
 func NewSolver(maxIterations int, verbose bool) *Solver {
 	return &Solver{
 		maxIterations: maxIterations,
 		verbose:       verbose,
+		guessing:      true,
+		registry:      DefaultRegistry(),
 	}
 }
 
-// Solve does an in-place update to the specified Grid by setting values and
-// iterating until complete or max iterations reached.
-func (s *Solver) Solve(grid *Grid) {
-
-	// Track Solve Time
-	startTime := time.Now()
-
-	// Loop until solved or MaxIterations reached
-	iteration := 0
-	for {
+// Registry returns the Registry of Strategies used by the Solver, which may be
+// modified to enable, disable, reorder, or add Strategies.
+func (s *Solver) Registry() *Registry {
+	return s.registry
+}
 
-		// Track completion based on whether the Grid was updated)
-		updated := false
+// SetRegistry replaces the Registry of Strategies used by the Solver.
+func (s *Solver) SetRegistry(registry *Registry) {
+	s.registry = registry
+}
 
-		// Track Iterations
-		iteration = iteration + 1
-		if s.verbose {
-			log.Printf("\n----- Iteration %d -----", iteration)
-		}
+// SetGuessing controls whether the Solver falls back to guessing when the
+// Strategies stall.  When disabled, a stalled Grid is left partially solved
+// with a Stalled status.
+func (s *Solver) SetGuessing(guessing bool) {
+	s.guessing = guessing
+}
 
-		// Set any Cells where only 1 value is still possible
-		updated = s.setSinglePossibleValueInGrid(grid)
+// SetAssumeUnique controls whether the Solver applies the Strategies which are
+// only valid for puzzles with a unique solution, such as Unique Rectangles.  These
+// are disabled by default, as they can reach a wrong solution for puzzles with
+// more than one.
+func (s *Solver) SetAssumeUnique(assumeUnique bool) {
+	s.assumeUnique = assumeUnique
+}
 
-		// Set any Cells where a possible value MUST belong for that Row
-		// because it has been eliminated from all other Cells in the Row
-		updated = updated || s.setOnlyPossibleValueInRow(grid)
+// SetTier controls the highest tier of Strategies the Solver applies.  Only the
+// StandardTier is applied by default, the ExoticTier patterns must be opted into.
+func (s *Solver) SetTier(tier StrategyTier) {
+	s.tier = tier
+}
 
-		// Set any Cells where a possible value MUST belong for that Column
-		// because it has been eliminated from all other Cells in the Column
-		updated = updated || s.setOnlyPossibleValueInCol(grid)
+// Solve does an in-place update to the specified Grid by setting values and
+// iterating until complete or max iterations reached.  Should the logical
+// techniques stall before the Grid is complete, the remaining Cells are
+// resolved by making "best guess" choices and backtracking as necessary.
+// Returns a SolveResult describing the outcome, including the ordered Steps of
+// the solution path, along with an UnsolvableError should a contradiction be
+// detected which prevents the Grid being solved.
+func (s *Solver) Solve(grid *Grid) (SolveResult, error) {
 
-		// Set any Cells where a possible value MUST belong for that Group
-		// because it has been eliminated from all other Cells in the Group
-		updated = updated || s.setOnlyPossibleValueInGroup(grid)
+	// Track Solve Time
+	startTime := time.Now()
+	result := newSolveResult()
 
-		// If no further updates were made then it should be solved!
-		if !updated {
-			break
-		}
+	// Solve as much as possible with the logical techniques, falling back to
+	// guessing if they stalled before completing the Grid
+	completed, err := s.solveLogically(grid, result)
+	if err == nil && completed && s.guessing && !grid.IsSolved() {
+		completed, err = s.solveByGuessing(grid, result)
+	}
 
-		// If we hit the max iterations then stop
-		if iteration >= MaxIterations {
-			log.Printf("Reached maximum iterations (%d) without solving", MaxIterations)
-			break
-		}
+	// Determine the outcome
+	switch {
+	case err != nil:
+		result.Status = Contradiction
+	case grid.IsSolved():
+		result.Status = Solved
+	case !completed:
+		result.Status = BudgetExceeded
+	default:
+		result.Status = Stalled
 	}
 
-	// Track solve time and log completion stats
-	solveTime := time.Since(startTime)
-	log.Printf("Finished solving in %d iterations over %s", iteration, solveTime.String())
+	// Track solve time and return the result
+	result.Elapsed = time.Since(startTime)
+	return *result, err
 }
 
-// setSinglePossibleValueInGrid updates the Grid by Setting the value of
-// any Cell which has only a single possible value remaining.
-func (s *Solver) setSinglePossibleValueInGrid(grid *Grid) bool {
-
-	// Track whether any updates wer made to the Grid
-	updated := false
-
-	// Loop over all the Cells in the Grid
-	for row := 0; row < 9; row++ {
-		for col := 0; col < 9; col++ {
-
-			// If the Cell only has a single remaining possible value, then set it!
-			possibleValues := grid.GetCell(row, col).GetPossibleValues()
-			if len(possibleValues) == 1 {
-				value := possibleValues[0]
-				s.logSetValueReason(row, col, value, "Only one possible value remaining for cell")
-				grid.SetValue(row, col, value)
-				updated = true
-			}
+// NextStep returns the easiest deduction which can be made in the Grid, i.e. the
+// first Step found by the enabled Strategies in order, without updating the Grid.
+// Returns false if the Grid is solved, contradictory, or no Strategy applies.
+func (s *Solver) NextStep(grid *Grid) (Step, bool) {
+	if grid.IsSolved() || grid.Validate() != nil {
+		return Step{}, false
+	}
+	for _, strategy := range s.registry.Strategies() {
+		if !s.applies(strategy) {
+			continue
+		}
+		if steps := strategy.Apply(grid.Copy()); len(steps) > 0 {
+			steps[0].Difficulty = strategy.Difficulty()
+			return steps[0], true
 		}
 	}
-
-	// Return Grid updated status
-	return updated
+	return Step{}, false
 }
 
-// setOnlyPossibleValueInRow updates the Grid by Setting the value of any Cell
-// which is the only remaining Cell in its Row with a particular possible Value.
-func (s *Solver) setOnlyPossibleValueInRow(grid *Grid) bool {
-
-	// Track whether any updates wer made to the Grid
-	updated := false
+// applies returns whether the Solver may apply the Strategy, given its tier and
+// whether it relies on a unique solution.
+func (s *Solver) applies(strategy Strategy) bool {
+	return (!requiresUniqueness(strategy) || s.assumeUnique) && strategyTier(strategy) <= s.tier
+}
 
-	// Loop over the 9 Rows
-	for row := 0; row < 9; row++ {
+// solveLogically updates the Grid by repeatedly applying the logical techniques
+// until no further progress is made or max iterations reached, accumulating the
+// iterations and placements in the SolveResult.  Returns false if max iterations
+// was reached, or an UnsolvableError as soon as a contradiction is detected.
+func (s *Solver) solveLogically(grid *Grid, result *SolveResult) (bool, error) {
 
-		// Loop over the possible Cell values (1-9)
-		for value := 1; value <= 9; value++ {
-			valueCount := 0
-			valueCol := -1
+	// Don't bother starting on a Grid which is already contradictory
+	if err := grid.Validate(); err != nil {
+		return true, err
+	}
 
-			// Loop over the 9 Columns for the current Row
-			for col := 0; col < 9; col++ {
+	// Loop until solved or max iterations reached
+	for iteration := 1; ; iteration++ {
 
-				// Check the Cell's possible values for the current value
-				if grid.GetCell(row, col).IsPossibleValue(value) {
-					valueCount = valueCount + 1
-					valueCol = col
-				}
+		// Track Iterations
+		result.Iterations = result.Iterations + 1
+		if s.verbose {
+			log.Printf("\n----- Iteration %d -----", iteration)
+		}
 
-				// If the value already exists then cease further checks
-				if valueCount > 1 {
-					valueCol = -1
-					break
-				}
+		// Apply the enabled Strategies in order, starting over from the
+		// easiest as soon as one makes progress
+		updated := false
+		for _, strategy := range s.registry.Strategies() {
+			if !s.applies(strategy) {
+				continue
 			}
-
-			// If only a single Cell in the Row has the possible value, then set it!
-			if valueCount == 1 {
-				s.logSetValueReason(row, valueCol, value, "Only cell in the row with this possible value")
-				grid.SetValue(row, valueCol, value)
+			steps := strategy.Apply(grid)
+			for _, step := range steps {
+				step.Difficulty = strategy.Difficulty()
+				s.logStep(step)
+				result.addStep(step)
+			}
+			if len(steps) > 0 {
 				updated = true
+				break
 			}
 		}
-	}
-
-	// Return Grid updated status
-	return updated
-}
-
-// setOnlyPossibleValueInCol updates the Grid by Setting the value of any Cell
-// which is the only remaining Cell in its Column with a particular possible Value.
-func (s *Solver) setOnlyPossibleValueInCol(grid *Grid) bool {
-
-	// Track whether any updates wer made to the Grid
-	updated := false
 
-	// Loop over the 9 Columns
-	for col := 0; col < 9; col++ {
+		// Stop as soon as the updates lead to a contradiction
+		if err := grid.Validate(); err != nil {
+			return true, err
+		}
 
-		// Loop over the possible Cell values (1-9)
-		for value := 1; value <= 9; value++ {
-			valueCount := 0
-			valueRow := -1
+		// If no further updates were made then it should be solved!
+		if !updated {
+			return true, nil
+		}
 
-			// Loop over the 9 Rows for the current Column
-			for row := 0; row < 9; row++ {
+		// If we hit the max iterations then stop
+		if result.Iterations >= s.maxIterations {
+			log.Printf("Reached maximum iterations (%d) without solving", s.maxIterations)
+			return false, nil
+		}
+	}
+}
 
-				// Check the Cell's possible values for the current value
-				if grid.GetCell(row, col).IsPossibleValue(value) {
-					valueCount = valueCount + 1
-					valueRow = row
-				}
+// solveByGuessing completes a stalled Grid by choosing the unknown Cell with the
+// fewest possible values and trying each of those values in turn on a copy of the
+// Grid.  Each guess is followed by the logical techniques and further guesses
+// until a consistent solution is found, which is then set in the original Grid
+// and its placements accumulated in the SolveResult.  Guesses leading to a
+// contradiction are abandoned (backtracked).  Returns false if max iterations was
+// reached, counting those of every guess, leaving the Grid unchanged, or an
+// UnsolvableError if no solution was found.
+func (s *Solver) solveByGuessing(grid *Grid, result *SolveResult) (bool, error) {
+
+	// Nothing to guess if the Grid is already solved, or is no longer solvable
+	if grid.IsSolved() {
+		return true, nil
+	}
+	if err := grid.Validate(); err != nil {
+		return true, err
+	}
 
-				// If the value already exists then cease further checks
-				if valueCount > 1 {
-					valueRow = -1
-					break
-				}
-			}
+	// Try each possible value of the most constrained Cell
+	row, col := grid.fewestPossibleValuesCell()
+	for _, value := range grid.GetCell(row, col).GetPossibleValues() {
 
-			// If only a single Cell in the Column has the possible value, then set it!
-			if valueCount == 1 {
-				s.logSetValueReason(valueRow, col, value, "Only cell in the column with possible value")
-				grid.SetValue(valueRow, col, value)
-				updated = true
-			}
+		// Stop once the iterations so far have used up the budget
+		if result.Iterations >= s.maxIterations {
+			return false, nil
 		}
-	}
 
-	// Return Grid updated status
-	return updated
-}
+		// Make the guess on a copy so it can be abandoned cleanly, counting its
+		// iterations on from those so far so the budget covers every guess
+		guess := grid.Copy()
+		guessResult := newSolveResult()
+		guessResult.Iterations = result.Iterations
+		step := Step{
+			Technique:  TechniqueGuess,
+			Placements: []Candidate{newCandidate(row, col, value)},
+			Cells:      []Position{{Row: row, Col: col}},
+			Difficulty: GuessDifficulty,
+			Reason:     "Best guess from the cell with fewest possible values",
+		}
+		s.logStep(step)
+		applyStep(guess, step)
+		guessResult.addGuess(step)
+
+		// Keep the first guess that leads to a consistent solution
+		completed, err := s.solveLogically(guess, guessResult)
+		if err == nil && completed {
+			completed, err = s.solveByGuessing(guess, guessResult)
+		}
+		guessResult.Iterations = guessResult.Iterations - result.Iterations
+		if err == nil && completed {
+			grid.setValuesFrom(guess)
+			result.merge(guessResult)
+			return true, nil
+		}
+		result.Iterations = result.Iterations + guessResult.Iterations
 
-// setOnlyPossibleValueInGroup updates the Grid by Setting the value of any Cell
-// which is the only remaining Cell in its Group with a particular possible Value.
-func (s *Solver) setOnlyPossibleValueInGroup(grid *Grid) bool {
-
-	// Track whether any updates wer made to the Grid
-	updated := false
-
-	// Loop over the 9 (sub) Groups selecting the upper-left Cell of each Group
-	for groupRow := 0; groupRow <= 6; groupRow = groupRow + 3 {
-		for groupCol := 0; groupCol <= 6; groupCol = groupCol + 3 {
-
-			// Loop over the possible Cell values (1-9)
-			for value := 1; value <= 9; value++ {
-				valueCount := 0
-				valueRow := -1
-				valueCol := -1
-
-			outerLoop:
-
-				// Loop over the 9 Cells in the Group
-				for groupCellRow := groupRow; groupCellRow < groupRow+3; groupCellRow++ {
-					for groupCellCol := groupCol; groupCellCol < groupCol+3; groupCellCol++ {
-
-						// Check the Cell's possible values for the current value
-						if grid.GetCell(groupCellRow, groupCellCol).IsPossibleValue(value) {
-							valueCount = valueCount + 1
-							valueRow = groupCellRow
-							valueCol = groupCellCol
-						}
-
-						// If the value already exists then cease further checks
-						if valueCount > 1 {
-							valueRow = -1
-							valueCol = -1
-							break outerLoop
-						}
-					}
-				}
-
-				// If only a single Cell in the Group has the possible value, then set it!
-				if valueCount == 1 {
-					s.logSetValueReason(valueRow, valueCol, value, "Only cell in the group with possible value")
-					grid.SetValue(valueRow, valueCol, value)
-					updated = true
-				}
-			}
+		// Running out of iterations says nothing about the guess, so stop
+		// rather than backtrack
+		if err == nil && !completed {
+			return false, nil
+		}
+		if s.verbose {
+			log.Printf("Backtrack: %s (Guess led to a contradiction: %v)", conclusion{Candidate: newCandidate(row, col, value)}, err)
 		}
 	}
 
-	// Return Grid updated status
-	return updated
+	// Every possible value led to a contradiction
+	return true, exhaustedGuessesError(Position{Row: row, Col: col})
 }
 
-// logSetValueReason logs a specific SetValue() decision with associated reason.
-func (s *Solver) logSetValueReason(row int, col int, value int, reason string) {
+// logStep logs the placements and eliminations of a Step, in rNcM notation, with
+// its associated reason.
+func (s *Solver) logStep(step Step) {
 	if s.verbose {
-		log.Printf("SetValue  [%d,%d] --> %d     %s", row, col, value, reason)
+		log.Printf("%s", step)
 	}
 }
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -11,6 +12,139 @@ func TestNewSolver(t *testing.T) {
 	assert.NotNil(t, solver)
 	assert.Equal(t, 99, solver.maxIterations)
 	assert.Equal(t, true, solver.verbose)
+	assert.Equal(t, true, solver.guessing)
+	assert.Equal(t, DefaultRegistry(), solver.Registry())
+}
+
+func TestSolver_SetRegistry(t *testing.T) {
+
+	// Create a Solver only able to find Naked Singles
+	registry := NewRegistry()
+	_ = registry.Register(&nakedSingleStrategy{})
+	solver := NewSolver(100, false)
+	solver.SetRegistry(registry)
+	solver.SetGuessing(false)
+	assert.Equal(t, registry, solver.Registry())
+
+	// Verify that it stalls without solving
+	grid := testGrid()
+	result, err := solver.Solve(grid)
+	assert.Nil(t, err)
+	assert.Equal(t, Stalled, result.Status)
+	assert.False(t, grid.IsSolved())
+	assert.Equal(t, []string{TechniqueNakedSingle}, mapKeys(result.Placements))
+}
+
+// mapKeys returns the keys of the map for comparison.
+func mapKeys(m map[string]int) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestSolver_SetAssumeUnique(t *testing.T) {
+
+	// Create a Unique Rectangle Type 1 in an empty Grid
+	newGrid := func() *Grid {
+		grid := NewGrid()
+		restrictCandidates(grid, 0, 0, 1, 2)
+		restrictCandidates(grid, 0, 4, 1, 2)
+		restrictCandidates(grid, 1, 0, 1, 2)
+		return grid
+	}
+	registry := NewRegistry()
+	assert.NoError(t, registry.Register(&uniqueRectangleStrategy{technique: TechniqueUniqueRectangle1}))
+	solver := NewSolver(10, false)
+	solver.SetRegistry(registry)
+	solver.SetGuessing(false)
+
+	// Verify the rectangle is ignored by default
+	grid := newGrid()
+	result, err := solver.Solve(grid)
+	assert.NoError(t, err)
+	assert.Equal(t, Stalled, result.Status)
+	assert.Equal(t, 1, result.Iterations)
+	assert.True(t, grid.GetCell(1, 4).IsPossibleValue(1))
+
+	// Verify the rectangle is used when assuming uniqueness
+	solver.SetAssumeUnique(true)
+	grid = newGrid()
+	result, err = solver.Solve(grid)
+	assert.NoError(t, err)
+	assert.Equal(t, Stalled, result.Status)
+	assert.Equal(t, 2, result.Iterations)
+	assert.False(t, grid.GetCell(1, 4).IsPossibleValue(1))
+	assert.False(t, requiresUniqueness(&nakedSingleStrategy{}))
+}
+
+func TestSolver_SetTier(t *testing.T) {
+	registry := NewRegistry()
+	assert.NoError(t, registry.Register(&skLoopStrategy{}))
+	solver := NewSolver(10, false)
+	solver.SetRegistry(registry)
+	solver.SetGuessing(false)
+
+	// Verify the SK-Loop is ignored by default
+	grid := testGridFromString(testEasterMonster)
+	result, err := solver.Solve(grid)
+	assert.NoError(t, err)
+	assert.Equal(t, Stalled, result.Status)
+	assert.True(t, grid.GetCell(1, 4).IsPossibleValue(3))
+
+	// Verify the SK-Loop is used when opting into the exotic tier
+	solver.SetTier(ExoticTier)
+	grid = testGridFromString(testEasterMonster)
+	result, err = solver.Solve(grid)
+	assert.NoError(t, err)
+	assert.Equal(t, Stalled, result.Status)
+	assert.Equal(t, 1, result.Deductions[TechniqueSKLoop])
+	assert.False(t, grid.GetCell(1, 4).IsPossibleValue(3))
+}
+
+func TestSolver_NextStep(t *testing.T) {
+	solver := NewSolver(10, false)
+
+	// Verify the easiest Step is found without updating the Grid
+	grid := testGrid()
+	step, found := solver.NextStep(grid)
+	assert.True(t, found)
+	assert.Equal(t, TechniqueNakedSingle, step.Technique)
+	assertValidSteps(t, testSolvedPuzzle, []Step{step})
+	assert.Equal(t, testGrid(), grid)
+
+	// Verify the Steps lead to the solution when applied one at a time
+	for step, found = solver.NextStep(grid); found; step, found = solver.NextStep(grid) {
+		applyStep(grid, step)
+	}
+	assert.True(t, grid.IsSolved())
+	assert.Equal(t, testGridFromString(testSolvedPuzzle), grid)
+
+	// Verify there is no Step for a contradictory Grid
+	grid = testGrid()
+	for col := 0; col < 9; col++ {
+		grid.GetCell(1, col).EliminateValue(9)
+	}
+	_, found = solver.NextStep(grid)
+	assert.False(t, found)
+}
+
+func TestSolver_NextStep_Tier(t *testing.T) {
+	registry := NewRegistry()
+	assert.NoError(t, registry.Register(&skLoopStrategy{}))
+	solver := NewSolver(10, false)
+	solver.SetRegistry(registry)
+
+	// Verify the SK-Loop is only found once the exotic tier is opted into
+	grid := testGridFromString(testEasterMonster)
+	_, found := solver.NextStep(grid)
+	assert.False(t, found)
+	solver.SetTier(ExoticTier)
+	step, found := solver.NextStep(grid)
+	assert.True(t, found)
+	assert.Equal(t, TechniqueSKLoop, step.Technique)
+	assert.True(t, grid.GetCell(1, 4).IsPossibleValue(3))
 }
 
 func TestSolve(t *testing.T) {
@@ -28,7 +162,14 @@ func TestSolve(t *testing.T) {
 
 	// Create a Solver and solve the puzzle!
 	solver := NewSolver(100, verbose)
-	solver.Solve(grid)
+	result, err := solver.Solve(grid)
+	assert.Nil(t, err)
+	assert.Equal(t, Solved, result.Status)
+	assert.Equal(t, 51, result.Filled) // 30 clues
+	assert.Greater(t, result.Iterations, 0)
+	assert.Greater(t, result.Elapsed, time.Duration(0))
+	assert.Equal(t, 0, result.Placements[TechniqueGuess])
+	assert.Equal(t, PatternLogic, result.Method())
 
 	// Verbose Log Grid - After
 	if verbose {
@@ -79,3 +220,161 @@ func TestSolve(t *testing.T) {
 		}
 	}
 }
+
+func TestSolve_Guessing(t *testing.T) {
+
+	// Create a Grid which the logical techniques alone cannot solve
+	grid := testGridFromString(testExtremePuzzle)
+	completed, err := NewSolver(100, false).solveLogically(grid, newSolveResult())
+	assert.True(t, completed)
+	assert.Nil(t, err)
+	assert.False(t, grid.IsSolved())
+
+	// Solve the puzzle with guessing and verify against the known solution
+	grid = testGridFromString(testExtremePuzzle)
+	solver := NewSolver(100, false)
+	result, err := solver.Solve(grid)
+	assert.Nil(t, err)
+	assert.Equal(t, testGridFromString(testExtremeSolution), grid)
+	assert.Equal(t, Solved, result.Status)
+	assert.Equal(t, 60, result.Filled) // 21 clues
+	assert.Greater(t, result.Placements[TechniqueGuess], 0)
+	assert.Equal(t, BruteForce, result.Method())
+}
+
+func TestSolve_ForcingChains(t *testing.T) {
+
+	// Solve a puzzle which needs forcing chains, but no guessing
+	grid := testGridFromString(".4.1.....9....8.4..67..45....3.65.....2....95..43......8..9.7.....5...13........4")
+	solver := NewSolver(100, false)
+	solver.SetGuessing(false)
+	result, err := solver.Solve(grid)
+	assert.Nil(t, err)
+	assert.Equal(t, Solved, result.Status)
+	assert.Equal(t, ForcingChains, result.Method())
+	assert.Equal(t, 0, result.Placements[TechniqueGuess])
+	assert.Greater(t, result.Deductions[TechniqueCellForcingChain]+result.Deductions[TechniqueNishio], 0)
+}
+
+func TestSolve_Steps(t *testing.T) {
+
+	// Define The TestCases
+	testCases := map[string]struct {
+		puzzle   string
+		guessing bool
+	}{
+		"Forcing Chains": {
+			puzzle: ".4.1.....9....8.4..67..45....3.65.....2....95..43......8..9.7.....5...13........4",
+		},
+		"Guessing": {
+			puzzle:   testExtremePuzzle,
+			guessing: true,
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			grid := testGridFromString(testCase.puzzle)
+			solver := NewSolver(200, false)
+			solver.SetGuessing(testCase.guessing)
+			result, err := solver.Solve(grid)
+			assert.Nil(t, err)
+			assert.Equal(t, Solved, result.Status)
+
+			// Verify every Step names the Cells involved, and replaying the
+			// solution path in order solves the puzzle
+			replay := testGridFromString(testCase.puzzle)
+			placed := 0
+			for _, step := range result.Steps {
+				assert.NotEmpty(t, step.Cells, step.String())
+				assert.NotEmpty(t, step.Reason, step.String())
+				applyStep(replay, step)
+				placed += len(step.Placements)
+			}
+			assert.Equal(t, result.Filled, placed)
+			assert.Equal(t, grid, replay)
+		})
+	}
+}
+
+func TestSolveByGuessing_Contradiction(t *testing.T) {
+
+	// Create a Grid where a value has no home in the second Row
+	grid := testGrid()
+	for col := 0; col < 9; col++ {
+		grid.GetCell(1, col).EliminateValue(9)
+	}
+
+	// Verify that no solution is found and the Grid is left untouched
+	solver := NewSolver(100, false)
+	completed, err := solver.solveByGuessing(grid, newSolveResult())
+	assert.True(t, completed)
+	assert.ErrorIs(t, err, ErrUnsolvable)
+	assert.Equal(t, 0, grid.GetCell(1, 0).GetValue())
+}
+
+func TestSolve_GuessingBudgetExceeded(t *testing.T) {
+
+	// Verify running out of iterations while following a guess is reported as
+	// such, rather than as a failed guess
+	grid := testGridFromString(testExtremePuzzle)
+	result, err := NewSolver(3, false).Solve(grid)
+	assert.Nil(t, err)
+	assert.Equal(t, BudgetExceeded, result.Status)
+	assert.False(t, grid.IsSolved())
+	assert.NotContains(t, result.Placements, TechniqueGuess)
+	assert.LessOrEqual(t, result.Iterations, 3)
+
+	// Verify the budget covers the iterations of every guess, not each one alone
+	grid = testGridFromString(testExtremePuzzle)
+	result, err = NewSolver(50, false).Solve(grid)
+	assert.Nil(t, err)
+	assert.Equal(t, BudgetExceeded, result.Status)
+	assert.Equal(t, 50, result.Iterations)
+	assert.False(t, grid.IsSolved())
+}
+
+func TestSolve_Status(t *testing.T) {
+
+	// Create a Grid where a value has no home in the second Row
+	contradiction := testGrid()
+	for col := 0; col < 9; col++ {
+		contradiction.GetCell(1, col).EliminateValue(9)
+	}
+
+	// Create a Grid where a value is set twice in a Row
+	repeated := testGrid()
+	repeated.GetCell(1, 0).SetValue(5)
+
+	// Create a Grid which is consistent but has no solution
+	unsolvable := testGridFromString("12345678........9")
+
+	// Define The TestCases
+	testCases := map[string]struct {
+		grid          *Grid
+		maxIterations int
+		expect        SolveStatus
+		expectErr     string
+	}{
+		"Solved":          {grid: testGrid(), maxIterations: 100, expect: Solved},
+		"Contradiction":   {grid: contradiction, maxIterations: 100, expect: Contradiction, expectErr: "digit 9 has no place in row 2"},
+		"Repeated Value":  {grid: repeated, maxIterations: 100, expect: Contradiction, expectErr: "digit 5 appears twice in row 2"},
+		"Unsolvable":      {grid: unsolvable, maxIterations: 100, expect: Contradiction, expectErr: "r1c9 has no candidates"},
+		"Budget Exceeded": {grid: testGrid(), maxIterations: 2, expect: BudgetExceeded},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			result, err := NewSolver(testCase.maxIterations, false).Solve(testCase.grid)
+			assert.Equal(t, testCase.expect, result.Status)
+			if testCase.expectErr == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrUnsolvable)
+				assert.EqualError(t, err, testCase.expectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Candidate is a possible value for the Cell at a specific Position.
+type Candidate struct {
+	Position
+	Value int
+}
+
+// newCandidate returns the Candidate for the value in the Cell at row/col.
+func newCandidate(row int, col int, value int) Candidate {
+	return Candidate{Position: Position{Row: row, Col: col}, Value: value}
+}
+
+// String returns the Candidate in the form "(5)r4c7".
+func (c Candidate) String() string {
+	return fmt.Sprintf("(%d)%s", c.Value, c.Position)
+}
+
+// Step is a single deduction made by a Strategy, consisting of the values it
+// placed and/or the possible values it eliminated from Cells, along with the
+// Cells and Houses forming the pattern which justified it.
+type Step struct {
+	Technique    string      // Name of the technique which made the deduction
+	Placements   []Candidate // Values set in Cells
+	Eliminations []Candidate // Possible values removed from Cells
+	Cells        []Position  // Cells forming the pattern behind the deduction
+	Houses       []House     // Houses in which the pattern lies
+	Difficulty   float64     // Difficulty of the Strategy which made the deduction
+	Reason       string      // Human readable reason for the deduction
+}
+
+// Conclusions returns the placements and eliminations of the Step in rNcM
+// notation, e.g. "r4c7=5, r1c2<>3".
+func (s Step) Conclusions() string {
+	conclusions := []string{}
+	for _, placement := range s.Placements {
+		conclusions = append(conclusions, conclusion{Candidate: placement, placed: true}.String())
+	}
+	for _, elimination := range s.Eliminations {
+		conclusions = append(conclusions, conclusion{Candidate: elimination}.String())
+	}
+	return strings.Join(conclusions, ", ")
+}
+
+// String returns the Step as a line of a solution path, in the form
+// "Naked Single: r4c7=5 (Only one possible value remaining for cell)".
+func (s Step) String() string {
+	return fmt.Sprintf("%s: %s (%s)", s.Technique, s.Conclusions(), s.Reason)
+}
+
+// applyStep updates the Grid with the placements and eliminations of the Step.
+func applyStep(grid *Grid, step Step) {
+	for _, placement := range step.Placements {
+		grid.SetValue(placement.Row, placement.Col, placement.Value)
+	}
+	for _, elimination := range step.Eliminations {
+		grid.GetCell(elimination.Row, elimination.Col).EliminateValue(elimination.Value)
+	}
+}
@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCandidate_String(t *testing.T) {
+	assert.Equal(t, "(5)r4c7", newCandidate(3, 6, 5).String())
+}
+
+func TestApplyStep(t *testing.T) {
+	grid := testGrid()
+	applyStep(grid, Step{
+		Technique:    "Test",
+		Placements:   []Candidate{newCandidate(3, 5, 1)},
+		Eliminations: []Candidate{newCandidate(0, 8, 3)},
+	})
+	assert.Equal(t, 1, grid.GetCell(3, 5).GetValue())
+	assert.False(t, grid.GetCell(3, 4).IsPossibleValue(1)) // Peers updated
+	assert.False(t, grid.GetCell(0, 8).IsPossibleValue(3))
+}
+
+func TestStep_String(t *testing.T) {
+	step := Step{
+		Technique:    TechniquePointing,
+		Placements:   []Candidate{newCandidate(3, 6, 5)},
+		Eliminations: []Candidate{newCandidate(0, 1, 3), newCandidate(0, 2, 3)},
+		Reason:       "Value 3 in box 2 is confined to cells r2c4,r2c5 shared with row 2",
+	}
+	assert.Equal(t, "r4c7=5, r1c2<>3, r1c3<>3", step.Conclusions())
+	assert.Equal(t, "Pointing: r4c7=5, r1c2<>3, r1c3<>3 (Value 3 in box 2 is confined to cells r2c4,r2c5 shared with row 2)", step.String())
+}
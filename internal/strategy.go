@@ -0,0 +1,265 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Strategy is a logical solving technique which the Solver applies to a Grid.
+type Strategy interface {
+
+	// Name returns the unique name of the technique, e.g. "Naked Single".
+	Name() string
+
+	// Difficulty returns the relative weight of the technique, where harder
+	// techniques for a human solver have larger weights.
+	Difficulty() float64
+
+	// Apply updates the Grid with any deductions the technique can make, and
+	// returns the Steps applied, or none if the technique made no progress.
+	Apply(grid *Grid) []Step
+}
+
+// requiresUniqueness returns whether the Strategy is only valid for puzzles with
+// a unique solution, i.e. it implements RequiresUniqueness() returning true.  The
+// Solver only applies such Strategies when it is set to assume uniqueness.
+func requiresUniqueness(strategy Strategy) bool {
+	unique, ok := strategy.(interface{ RequiresUniqueness() bool })
+	return ok && unique.RequiresUniqueness()
+}
+
+// StrategyTier groups the Strategies by how specialized they are.  The Solver only
+// applies the Strategies up to its chosen tier, so the high-end patterns used to
+// analyse the hardest puzzles are opt-in.
+type StrategyTier int
+
+const (
+	StandardTier StrategyTier = iota // The techniques applied by default
+	ExoticTier                       // High-end patterns for the hardest puzzles, such as Exocets
+)
+
+// String returns the lower case name of the StrategyTier, as accepted by
+// ParseStrategyTier().
+func (t StrategyTier) String() string {
+	switch t {
+	case StandardTier:
+		return "standard"
+	case ExoticTier:
+		return "exotic"
+	default:
+		return fmt.Sprintf("StrategyTier(%d)", int(t))
+	}
+}
+
+// ParseStrategyTier returns the StrategyTier with the specified name, or an error
+// if there is no such tier.
+func ParseStrategyTier(name string) (StrategyTier, error) {
+	for _, tier := range []StrategyTier{StandardTier, ExoticTier} {
+		if tier.String() == name {
+			return tier, nil
+		}
+	}
+	return StandardTier, fmt.Errorf("unknown strategy tier '%s' must be one of standard,exotic", name)
+}
+
+// strategyTier returns the StrategyTier of the Strategy, i.e. the result of its
+// Tier() method if it has one, otherwise the StandardTier.
+func strategyTier(strategy Strategy) StrategyTier {
+	if tiered, ok := strategy.(interface{ Tier() StrategyTier }); ok {
+		return tiered.Tier()
+	}
+	return StandardTier
+}
+
+// Registry holds an ordered set of Strategies, each of which may be enabled or
+// disabled.  The Solver applies the enabled Strategies in order, returning to
+// the first whenever one makes progress.
+type Registry struct {
+	entries []*registryEntry
+}
+
+// registryEntry is a single Strategy in the Registry and its enabled state.
+type registryEntry struct {
+	strategy Strategy
+	enabled  bool
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// DefaultRegistry returns a Registry holding all the built-in Strategies, enabled
+// and ordered roughly from easiest to hardest, though naked singles come first as
+// the quickest way to make progress.
+func DefaultRegistry() *Registry {
+	registry := NewRegistry()
+	for _, strategy := range []Strategy{
+		&nakedSingleStrategy{},
+		&hiddenSingleStrategy{kind: RowHouse},
+		&hiddenSingleStrategy{kind: ColHouse},
+		&hiddenSingleStrategy{kind: GroupHouse},
+		&pointingStrategy{},
+		&claimingStrategy{},
+		&nakedSubsetStrategy{size: 2},
+		&fishStrategy{size: 2},
+		&twoLinkStrategy{technique: TechniqueSkyscraper},
+		&twoLinkStrategy{technique: TechniqueTwoStringKite},
+		&hiddenSubsetStrategy{size: 2},
+		&twoLinkStrategy{technique: TechniqueTurbotFish},
+		&fishStrategy{size: 2, finned: true},
+		&emptyRectangleStrategy{},
+		&coloringStrategy{},
+		&nakedSubsetStrategy{size: 3},
+		&fishStrategy{size: 3},
+		&hiddenSubsetStrategy{size: 3},
+		&coloringStrategy{multi: true},
+		&fishStrategy{size: 3, finned: true},
+		newXYWingStrategy(),
+		newXYZWingStrategy(),
+		&uniqueRectangleStrategy{technique: TechniqueUniqueRectangle1},
+		&uniqueRectangleStrategy{technique: TechniqueUniqueRectangle2},
+		&uniqueRectangleStrategy{technique: TechniqueUniqueRectangle4},
+		&uniqueRectangleStrategy{technique: TechniqueUniqueRectangle5},
+		newWXYZWingStrategy(),
+		&uniqueRectangleStrategy{technique: TechniqueUniqueRectangle3},
+		&uniqueRectangleStrategy{technique: TechniqueUniqueRectangle6},
+		&chainStrategy{technique: TechniqueXChain},
+		&uniqueRectangleStrategy{technique: TechniqueHiddenUniqueRectangle},
+		&chainStrategy{technique: TechniqueXYChain},
+		&chainStrategy{technique: TechniqueAIC},
+		&nakedSubsetStrategy{size: 4},
+		&sueDeCoqStrategy{},
+		&chainStrategy{technique: TechniqueGroupedAIC},
+		&fishStrategy{size: 4},
+		&sueDeCoqStrategy{extended: true},
+		&hiddenSubsetStrategy{size: 4},
+		&fishStrategy{size: 4, finned: true},
+		&alsStrategy{technique: TechniqueALSXZ},
+		&bugPlusOneStrategy{},
+		&alsStrategy{technique: TechniqueALSXYWing},
+		&alsStrategy{technique: TechniqueDeathBlossom},
+		&skLoopStrategy{},
+		&juniorExocetStrategy{},
+		&patternOverlayStrategy{},
+		&mslsStrategy{},
+		&forcingStrategy{technique: TechniqueCellForcingChain},
+		&forcingStrategy{technique: TechniqueUnitForcingChain},
+		&forcingStrategy{technique: TechniqueNishio},
+	} {
+		_ = registry.Register(strategy) // Built-in names are unique
+	}
+	return registry
+}
+
+// Register appends an enabled Strategy to the end of the Registry, or returns an
+// error if a Strategy with the same name is already registered.
+func (r *Registry) Register(strategy Strategy) error {
+	if r.find(strategy.Name()) != nil {
+		return fmt.Errorf("strategy '%s' is already registered", strategy.Name())
+	}
+	r.entries = append(r.entries, &registryEntry{strategy: strategy, enabled: true})
+	return nil
+}
+
+// Enable turns on the named Strategy, or returns an error if it is not registered.
+func (r *Registry) Enable(name string) error {
+	return r.setEnabled(name, true)
+}
+
+// Disable turns off the named Strategy, or returns an error if it is not registered.
+func (r *Registry) Disable(name string) error {
+	return r.setEnabled(name, false)
+}
+
+// IsEnabled returns whether the named Strategy is registered and enabled.
+func (r *Registry) IsEnabled(name string) bool {
+	entry := r.find(name)
+	return entry != nil && entry.enabled
+}
+
+// SetOrder moves the named Strategies to the front of the Registry in the order
+// given, leaving the remaining Strategies after them in their existing order.
+// Returns an error, leaving the order unchanged, if any name is not registered.
+func (r *Registry) SetOrder(names ...string) error {
+	ordered := make([]*registryEntry, 0, len(r.entries))
+	for _, name := range names {
+		entry := r.find(name)
+		if entry == nil {
+			return unknownStrategyError(name)
+		}
+		ordered = append(ordered, entry)
+	}
+	for _, entry := range r.entries {
+		if !containsEntry(ordered, entry) {
+			ordered = append(ordered, entry)
+		}
+	}
+	r.entries = ordered
+	return nil
+}
+
+// Names returns the names of all registered Strategies in order.
+func (r *Registry) Names() []string {
+	names := make([]string, len(r.entries))
+	for index, entry := range r.entries {
+		names[index] = entry.strategy.Name()
+	}
+	return names
+}
+
+// Strategies returns the enabled Strategies in order.
+func (r *Registry) Strategies() []Strategy {
+	strategies := []Strategy{}
+	for _, entry := range r.entries {
+		if entry.enabled {
+			strategies = append(strategies, entry.strategy)
+		}
+	}
+	return strategies
+}
+
+// byDifficulty returns a new Registry holding the enabled Strategies ordered from
+// easiest to hardest by their difficulty, keeping the existing order of equals.
+func (r *Registry) byDifficulty() *Registry {
+	strategies := r.Strategies()
+	sort.SliceStable(strategies, func(i, j int) bool {
+		return strategies[i].Difficulty() < strategies[j].Difficulty()
+	})
+	sorted := NewRegistry()
+	for _, strategy := range strategies {
+		sorted.entries = append(sorted.entries, &registryEntry{strategy: strategy, enabled: true})
+	}
+	return sorted
+}
+
+func (r *Registry) setEnabled(name string, enabled bool) error {
+	entry := r.find(name)
+	if entry == nil {
+		return unknownStrategyError(name)
+	}
+	entry.enabled = enabled
+	return nil
+}
+
+func (r *Registry) find(name string) *registryEntry {
+	for _, entry := range r.entries {
+		if entry.strategy.Name() == name {
+			return entry
+		}
+	}
+	return nil
+}
+
+func containsEntry(entries []*registryEntry, entry *registryEntry) bool {
+	for _, existing := range entries {
+		if existing == entry {
+			return true
+		}
+	}
+	return false
+}
+
+func unknownStrategyError(name string) error {
+	return fmt.Errorf("strategy '%s' is not registered", name)
+}
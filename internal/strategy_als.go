@@ -0,0 +1,354 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Names of the almost locked set techniques, as reported in a SolveResult.
+const (
+	TechniqueALSXZ        = "ALS-XZ"        // 2 ALSs joined by a restricted common value
+	TechniqueALSXYWing    = "ALS-XY-Wing"   // 2 ALSs each joined to a pivot ALS
+	TechniqueDeathBlossom = "Death Blossom" // A stem Cell with an ALS petal for each of its values
+)
+
+// maxDeathBlossomValues limits the number of possible values in a Death Blossom stem.
+const maxDeathBlossomValues = 3
+
+// cellSet is a set of Cells of the Grid, as a bit per Cell in Row order.
+type cellSet [2]uint64
+
+// cellSetOf returns the set holding the Positions.
+func cellSetOf(positions ...Position) cellSet {
+	set := cellSet{}
+	for _, position := range positions {
+		index := position.Row*9 + position.Col
+		set[index/64] |= 1 << (index % 64)
+	}
+	return set
+}
+
+// peerSets holds the set of peers of each Cell, in Row order.
+var peerSets = func() [81]cellSet {
+	sets := [81]cellSet{}
+	for index := range sets {
+		sets[index] = cellSetOf(Position{Row: index / 9, Col: index % 9}.peers()...)
+	}
+	return sets
+}()
+
+func (s cellSet) and(other cellSet) cellSet    { return cellSet{s[0] & other[0], s[1] & other[1]} }
+func (s cellSet) or(other cellSet) cellSet     { return cellSet{s[0] | other[0], s[1] | other[1]} }
+func (s cellSet) andNot(other cellSet) cellSet { return cellSet{s[0] &^ other[0], s[1] &^ other[1]} }
+func (s cellSet) isEmpty() bool                { return s[0] == 0 && s[1] == 0 }
+
+// positions returns the Positions in the set, in Row order.
+func (s cellSet) positions() []Position {
+	positions := []Position{}
+	for index := 0; index < 81; index++ {
+		if s[index/64]&(1<<(index%64)) != 0 {
+			positions = append(positions, Position{Row: index / 9, Col: index % 9})
+		}
+	}
+	return positions
+}
+
+// seenByAll returns the set of Cells which see every Cell in the set.
+func (s cellSet) seenByAll() cellSet {
+	seen := cellSet{^uint64(0), ^uint64(0)}
+	for _, position := range s.positions() {
+		seen = seen.and(peerSets[position.Row*9+position.Col])
+	}
+	return seen
+}
+
+// als is an almost locked set, N unsolved Cells of a House with only N+1 possible
+// values between them.  Should any one of the values be removed from the set, the
+// remaining N values would be locked into its Cells.
+type als struct {
+	house  House
+	cells  cellSet
+	values candidateMask
+	holds  [10]cellSet // The Cells of the set where each value is possible
+	seen   [10]cellSet // The Cells outside the set which see every Cell holding each value
+}
+
+// String returns the ALS in the form "r1c1,r1c2 {1,2,5}".
+func (a *als) String() string {
+	return fmt.Sprintf("%s %s", formatPositions(a.cells.positions()), a.values)
+}
+
+// restrictedCommon returns whether the value is a restricted common candidate of
+// the 2 ALSs, i.e. possible in both and every Cell of one holding it sees every
+// Cell of the other holding it, so at most one of them can hold the value.
+func (a *als) restrictedCommon(other *als, value int) bool {
+	return a.values.has(value) && other.values.has(value) &&
+		other.holds[value].andNot(a.seen[value]).isEmpty()
+}
+
+// alsIndex lists every almost locked set in a Grid, along with the possible Cells
+// for each value, for reuse by the ALS techniques.
+type alsIndex struct {
+	sets     []*als
+	possible [10]cellSet // The Cells where each value is possible
+}
+
+// newALSIndex returns the index of every distinct ALS in every House of the Grid.
+func newALSIndex(grid *Grid) *alsIndex {
+	index := &alsIndex{}
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			for _, value := range grid.GetCell(row, col).possibleMask().values() {
+				index.possible[value] = index.possible[value].or(cellSetOf(Position{Row: row, Col: col}))
+			}
+		}
+	}
+
+	// Try every subset of the unsolved Cells in each House
+	found := map[cellSet]bool{}
+	for _, house := range allHouses {
+		unsolved := []Position{}
+		for _, position := range house.Positions() {
+			if grid.GetCell(position.Row, position.Col).GetValue() == 0 {
+				unsolved = append(unsolved, position)
+			}
+		}
+		for size := 1; size < len(unsolved); size++ {
+			combinations(len(unsolved), size, func(indexes []int) bool {
+				positions := make([]Position, size)
+				values := candidateMask(0)
+				for offset, cell := range indexes {
+					positions[offset] = unsolved[cell]
+					values |= grid.GetCell(unsolved[cell].Row, unsolved[cell].Col).possibleMask()
+				}
+				cells := cellSetOf(positions...)
+				if values.count() != size+1 || found[cells] {
+					return false
+				}
+				found[cells] = true
+				set := &als{house: house, cells: cells, values: values}
+				for _, value := range values.values() {
+					set.holds[value] = cells.and(index.possible[value])
+					set.seen[value] = set.holds[value].seenByAll()
+				}
+				index.sets = append(index.sets, set)
+				return false
+			})
+		}
+	}
+	return index
+}
+
+// eliminations returns the eliminations of the value from every Cell outside the
+// excluded Cells which sees every Cell holding it in each of the ALSs.
+func (i *alsIndex) eliminations(value int, excluded cellSet, sets ...*als) []Candidate {
+	targets := i.possible[value].andNot(excluded)
+	for _, set := range sets {
+		targets = targets.and(set.seen[value])
+	}
+	return candidatesOf(targets.positions(), value)
+}
+
+// alsStrategy eliminates values using almost locked sets joined by restricted
+// common candidates (RCCs), values which at most one of 2 ALSs can hold.  For
+// ALS-XZ, 2 ALSs joined by an RCC x cannot both lose a value, so for any other
+// value z common to both, one of them holds z.  For ALS-XY-Wing, 2 ALSs joined to
+// a pivot ALS by different RCCs x and y similarly must hold any common value z.
+// For a Death Blossom, a stem Cell with an ALS petal for each of its values, where
+// the petal's Cells holding the value all see the stem, must have one petal lose
+// its stem value, so the petals must hold any value z common to all of them.  In
+// each case z is eliminated from every Cell which sees all the Cells holding it.
+type alsStrategy struct {
+	technique string
+}
+
+func (a *alsStrategy) Name() string { return a.technique }
+func (a *alsStrategy) Difficulty() float64 {
+	return map[string]float64{
+		TechniqueALSXZ:        5.5,
+		TechniqueALSXYWing:    5.7,
+		TechniqueDeathBlossom: 5.9,
+	}[a.technique]
+}
+
+func (a *alsStrategy) Apply(grid *Grid) []Step {
+	index := newALSIndex(grid)
+	var step *Step
+	switch a.technique {
+	case TechniqueALSXZ:
+		step = index.findALSXZ()
+	case TechniqueALSXYWing:
+		step = index.findALSXYWing()
+	default:
+		step = index.findDeathBlossom(grid)
+	}
+	if step == nil {
+		return nil
+	}
+	step.Technique = a.technique
+	applyStep(grid, *step)
+	return []Step{*step}
+}
+
+// findALSXZ returns the Step for the first pair of ALSs joined by an RCC which
+// eliminates a common value, or nil.
+func (i *alsIndex) findALSXZ() *Step {
+	for _, first := range i.sets {
+		for _, second := range i.sets {
+			if first == second || !first.cells.and(second.cells).isEmpty() {
+				continue
+			}
+			for _, x := range (first.values & second.values).values() {
+				if !first.restrictedCommon(second, x) {
+					continue
+				}
+				if step := i.commonValueStep(maskOf(x), first.cells.or(second.cells), first, second); step != nil {
+					step.Reason = fmt.Sprintf("ALS %s and ALS %s are joined by restricted common value %d, %s",
+						first, second, x, step.Reason)
+					return step
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// findALSXYWing returns the Step for the first 2 ALSs joined to a pivot ALS by
+// different RCCs which eliminates a common value, or nil.
+func (i *alsIndex) findALSXYWing() *Step {
+	for _, pivot := range i.sets {
+
+		// Collect the ALSs joined to the pivot, by the RCCs joining them
+		joined := map[*als]candidateMask{}
+		wings := []*als{}
+		for _, set := range i.sets {
+			if set == pivot || !set.cells.and(pivot.cells).isEmpty() {
+				continue
+			}
+			for _, value := range (set.values & pivot.values).values() {
+				if set.restrictedCommon(pivot, value) {
+					if joined[set] == 0 {
+						wings = append(wings, set)
+					}
+					joined[set] |= maskOf(value)
+				}
+			}
+		}
+
+		// Look for 2 wings joined by different RCCs with a common value
+		for firstIndex, first := range wings {
+			for _, second := range wings[firstIndex+1:] {
+				if !first.cells.and(second.cells).isEmpty() {
+					continue
+				}
+				for _, x := range joined[first].values() {
+					for _, y := range (joined[second] &^ maskOf(x)).values() {
+						excluded := first.cells.or(second.cells).or(pivot.cells)
+						if step := i.commonValueStep(maskOf(x, y), excluded, first, second); step != nil {
+							step.Reason = fmt.Sprintf("ALS %s and ALS %s are joined to pivot ALS %s by restricted common values %d and %d, %s",
+								first, second, pivot, x, y, step.Reason)
+							return step
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// findDeathBlossom returns the Step for the first stem Cell with a petal ALS for
+// each of its values which eliminates a value common to the petals, or nil.
+func (i *alsIndex) findDeathBlossom(grid *Grid) *Step {
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			stem := Position{Row: row, Col: col}
+			stemValues := grid.GetCell(row, col).possibleMask()
+			if stemValues.count() < 2 || stemValues.count() > maxDeathBlossomValues {
+				continue
+			}
+
+			// Collect the possible petals for each value of the stem
+			stemSet := cellSetOf(stem)
+			petals := map[int][]*als{}
+			for _, value := range stemValues.values() {
+				for _, set := range i.sets {
+					if set.values.has(value) && set.cells.and(stemSet).isEmpty() &&
+						set.holds[value].andNot(peerSets[row*9+col]).isEmpty() {
+						petals[value] = append(petals[value], set)
+					}
+				}
+			}
+
+			// Try every choice of disjoint petals, one per value of the stem
+			if step := i.blossomStep(stem, stemValues.values(), petals, nil); step != nil {
+				return step
+			}
+		}
+	}
+	return nil
+}
+
+// blossomStep chooses a petal for each of the remaining values of the stem,
+// disjoint from those already chosen, and returns the Step for the first complete
+// Death Blossom which eliminates a value common to all the petals, or nil.
+func (i *alsIndex) blossomStep(stem Position, values []int, petals map[int][]*als, chosen []*als) *Step {
+	if len(chosen) == len(values) {
+		excluded := cellSetOf(stem)
+		for _, petal := range chosen {
+			excluded = excluded.or(petal.cells)
+		}
+		step := i.commonValueStep(maskOf(values...), excluded, chosen...)
+		if step == nil {
+			return nil
+		}
+		descriptions := make([]string, len(chosen))
+		for index, petal := range chosen {
+			descriptions[index] = fmt.Sprintf("%d: ALS %s", values[index], petal)
+		}
+		step.Cells = append([]Position{stem}, step.Cells...)
+		step.Reason = fmt.Sprintf("Stem %s with petals %s, %s", stem, strings.Join(descriptions, ", "), step.Reason)
+		return step
+	}
+	for _, petal := range petals[values[len(chosen)]] {
+		disjoint := true
+		for _, other := range chosen {
+			if !petal.cells.and(other.cells).isEmpty() {
+				disjoint = false
+			}
+		}
+		if !disjoint {
+			continue
+		}
+		if step := i.blossomStep(stem, values, petals, append(chosen, petal)); step != nil {
+			return step
+		}
+	}
+	return nil
+}
+
+// commonValueStep returns the Step eliminating the first value z common to all the
+// ALSs, other than the linking values, from every Cell outside the excluded Cells
+// which sees all of their Cells holding z, or nil if none make progress.  The
+// Step's Reason is completed by the caller.
+func (i *alsIndex) commonValueStep(linking candidateMask, excluded cellSet, sets ...*als) *Step {
+	common := candidateMask(0x1ff)
+	for _, set := range sets {
+		common &= set.values
+	}
+	for _, z := range (common &^ linking).values() {
+		eliminations := i.eliminations(z, excluded, sets...)
+		if len(eliminations) > 0 {
+			step := &Step{
+				Eliminations: eliminations,
+				Reason:       fmt.Sprintf("so one of them holds value %d", z),
+			}
+			for _, set := range sets {
+				step.Cells = append(step.Cells, set.cells.positions()...)
+				step.Houses = append(step.Houses, set.house)
+			}
+			return step
+		}
+	}
+	return nil
+}
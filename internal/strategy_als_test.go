@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCellSet(t *testing.T) {
+	first := cellSetOf(Position{0, 0}, Position{8, 8})
+	second := cellSetOf(Position{8, 8}, Position{4, 4})
+	assert.Equal(t, []Position{{0, 0}, {8, 8}}, first.positions())
+	assert.Equal(t, []Position{{8, 8}}, first.and(second).positions())
+	assert.Equal(t, []Position{{0, 0}, {4, 4}, {8, 8}}, first.or(second).positions())
+	assert.Equal(t, []Position{{0, 0}}, first.andNot(second).positions())
+	assert.True(t, first.andNot(first).isEmpty())
+	assert.Equal(t, []Position{{0, 8}, {8, 0}}, first.seenByAll().positions())
+	assert.Len(t, peerSets[40].positions(), 20)
+}
+
+func TestNewALSIndex(t *testing.T) {
+
+	// Unsolve 3 Cells of row 1, which share box 1
+	grid := testGridFromString(testSolvedPuzzle)
+	unsolveCell(grid, 0, 0, 2, 5)
+	unsolveCell(grid, 0, 1, 5, 6)
+	unsolveCell(grid, 0, 2, 1, 2)
+
+	// Verify each Cell and the pairs with 3 values are distinct ALSs
+	index := newALSIndex(grid)
+	names := []string{}
+	for _, set := range index.sets {
+		names = append(names, set.String())
+	}
+	assert.Equal(t, []string{"r1c1 {2,5}", "r1c2 {5,6}", "r1c3 {1,2}", "r1c1,r1c2 {2,5,6}", "r1c1,r1c3 {1,2,5}"}, names)
+	assert.Equal(t, House{Kind: RowHouse, Index: 0}, index.sets[3].house)
+	assert.Equal(t, []Position{{0, 0}, {0, 1}}, index.sets[3].holds[5].positions())
+	assert.Equal(t, []Position{{0, 1}}, index.sets[0].seen[5].and(index.possible[5]).positions())
+	assert.True(t, index.sets[0].restrictedCommon(index.sets[1], 5))
+	assert.False(t, index.sets[0].restrictedCommon(index.sets[1], 2))
+}
+
+func TestALSStrategy(t *testing.T) {
+
+	// Define The TestCases, unsolving the listed Cells of the solved Grid
+	testCases := map[string]struct {
+		technique  string
+		difficulty float64
+		unsolved   map[Position][]int
+		eliminated []Candidate
+		reason     string
+	}{
+		"ALS-XZ": {
+			technique:  TechniqueALSXZ,
+			difficulty: 5.5,
+			unsolved:   map[Position][]int{{0, 0}: {1, 2}, {4, 0}: {1, 3}, {4, 1}: {2, 3}, {3, 0}: {2, 4}},
+			eliminated: []Candidate{newCandidate(3, 0, 2)},
+			reason:     "ALS r5c2 {2,3} and ALS r1c1,r5c1 {1,2,3} are joined by restricted common value 3, so one of them holds value 2",
+		},
+		"ALS-XY-Wing": {
+			technique:  TechniqueALSXYWing,
+			difficulty: 5.7,
+			unsolved:   map[Position][]int{{0, 0}: {1, 2}, {0, 4}: {1, 3}, {4, 0}: {2, 3}, {4, 4}: {3, 5}},
+			eliminated: []Candidate{newCandidate(4, 4, 3)},
+			reason:     "ALS r1c5 {1,3} and ALS r5c1 {2,3} are joined to pivot ALS r1c1 {1,2} by restricted common values 1 and 2, so one of them holds value 3",
+		},
+		"Death Blossom": {
+			technique:  TechniqueDeathBlossom,
+			difficulty: 5.9,
+			unsolved:   map[Position][]int{{4, 4}: {1, 2}, {0, 4}: {1, 3}, {4, 0}: {2, 3}, {0, 0}: {3, 5}},
+			eliminated: []Candidate{newCandidate(0, 0, 3)},
+			reason:     "Stem r5c5 with petals 1: ALS r1c5 {1,3}, 2: ALS r5c1 {2,3}, so one of them holds value 3",
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			strategy := &alsStrategy{technique: testCase.technique}
+			assert.Equal(t, testCase.technique, strategy.Name())
+			assert.Equal(t, testCase.difficulty, strategy.Difficulty())
+
+			// Create the sets in the solved Grid
+			grid := testGridFromString(testSolvedPuzzle)
+			for position, values := range testCase.unsolved {
+				unsolveCell(grid, position.Row, position.Col, values...)
+			}
+
+			// Verify the sets are found and the value eliminated
+			steps := strategy.Apply(grid)
+			assert.Len(t, steps, 1)
+			assert.Equal(t, testCase.technique, steps[0].Technique)
+			assert.Equal(t, testCase.reason, steps[0].Reason)
+			assert.ElementsMatch(t, testCase.eliminated, steps[0].Eliminations)
+			assert.Empty(t, strategy.Apply(grid))
+		})
+	}
+}
+
+func TestALSStrategy_Puzzle(t *testing.T) {
+	puzzle := "...6.....39.....41......3.......1...9....4.7.68....2...6..8..5..4.7....9.2.4.976."
+	solution := "812643597395278641476915382257861934931524876684397215769182453548736129123459768"
+	for _, technique := range []string{TechniqueALSXZ, TechniqueALSXYWing, TechniqueDeathBlossom} {
+		t.Run(technique, func(t *testing.T) {
+			steps := testCollectSteps(testGridFromString(puzzle), &alsStrategy{technique: technique})
+			assert.NotEmpty(t, steps)
+			assertValidSteps(t, solution, steps)
+		})
+	}
+}
@@ -0,0 +1,387 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Names of the chain techniques, as reported in a SolveResult.
+const (
+	TechniqueXChain     = "X-Chain"     // Alternating chain of strong and weak links for a single value
+	TechniqueXYChain    = "XY-Chain"    // Alternating chain through bivalue Cells
+	TechniqueAIC        = "AIC"         // Alternating inference chain mixing Cells and values
+	TechniqueGroupedAIC = "Grouped AIC" // Alternating inference chain including grouped nodes
+)
+
+// maxChainNodes limits the length of the chains searched for.
+const maxChainNodes = 20
+
+// chainNode is a node of an alternating inference chain, the value in a single
+// Cell, or grouped in 2-3 Cells of a Group/line intersection (true when any of
+// the Cells holds the value).
+type chainNode struct {
+	value     int
+	positions []Position
+}
+
+// String returns the node in Eureka notation, e.g. "(5)r1c2" or "(5)r1c23".
+func (n chainNode) String() string {
+	if len(n.positions) == 1 {
+		return newCandidate(n.positions[0].Row, n.positions[0].Col, n.value).String()
+	}
+	rows, cols := "", ""
+	for _, position := range n.positions {
+		if !strings.Contains(rows, fmt.Sprint(position.Row+1)) {
+			rows += fmt.Sprint(position.Row + 1)
+		}
+		if !strings.Contains(cols, fmt.Sprint(position.Col+1)) {
+			cols += fmt.Sprint(position.Col + 1)
+		}
+	}
+	return fmt.Sprintf("(%d)r%sc%s", n.value, rows, cols)
+}
+
+// seesAllOf returns whether every Cell of the node sees every Cell of the other,
+// so the 2 nodes cannot both be true.
+func (n chainNode) seesAllOf(other chainNode) bool {
+	for _, position := range n.positions {
+		for _, otherPosition := range other.positions {
+			if !position.sees(otherPosition) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// chainGraph is the graph of strong links (at least one node is true) and weak
+// links (at most one node is true) between the candidates of a Grid.
+type chainGraph struct {
+	nodes  []chainNode
+	strong [][]int
+	weak   [][]int
+}
+
+// chainLinks selects the kinds of node and link used to build a chainGraph.
+type chainLinks struct {
+	houseStrong bool // Strong links between the 2 places for a value in a House
+	cellStrong  bool // Strong links between the 2 values of a bivalue Cell
+	cellWeak    bool // Weak links between the values of the same Cell
+	groups      bool // Grouped nodes in Group/line intersections
+}
+
+// newChainGraph builds the graph of the selected links for the Grid.
+func newChainGraph(grid *Grid, links chainLinks) *chainGraph {
+	graph := &chainGraph{}
+	singles := map[Candidate]int{}
+	add := func(node chainNode) int {
+		graph.nodes = append(graph.nodes, node)
+		graph.strong = append(graph.strong, nil)
+		graph.weak = append(graph.weak, nil)
+		return len(graph.nodes) - 1
+	}
+
+	// Create a node for every possible value of every empty Cell
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			for _, value := range grid.GetCell(row, col).possibleMask().values() {
+				singles[newCandidate(row, col, value)] = add(chainNode{value: value, positions: []Position{{Row: row, Col: col}}})
+			}
+		}
+	}
+
+	// Create a grouped node for each value possible in 2-3 Cells of an intersection
+	groups := map[House][]int{}
+	if links.groups {
+		for _, meet := range allIntersections {
+			for value := 1; value <= 9; value++ {
+				positions := []Position{}
+				for _, position := range meet.positions {
+					if grid.GetCell(position.Row, position.Col).IsPossibleValue(value) {
+						positions = append(positions, position)
+					}
+				}
+				if len(positions) >= 2 {
+					id := add(chainNode{value: value, positions: positions})
+					groups[meet.group] = append(groups[meet.group], id)
+					groups[meet.line] = append(groups[meet.line], id)
+				}
+			}
+		}
+	}
+
+	// Link the 2 values of each bivalue Cell
+	linked := map[[2]int]bool{}
+	link := func(adjacent [][]int, first int, second int) {
+		if !linked[[2]int{first, second}] {
+			linked[[2]int{first, second}] = true
+			adjacent[first] = append(adjacent[first], second)
+			adjacent[second] = append(adjacent[second], first)
+		}
+	}
+	if links.cellStrong {
+		for row := 0; row < 9; row++ {
+			for col := 0; col < 9; col++ {
+				if values := grid.GetCell(row, col).possibleMask().values(); len(values) == 2 {
+					link(graph.strong, singles[newCandidate(row, col, values[0])], singles[newCandidate(row, col, values[1])])
+				}
+			}
+		}
+	}
+
+	// Link the 2 nodes which together cover every place for a value in a House
+	if links.houseStrong {
+		for _, house := range allHouses {
+			for value := 1; value <= 9; value++ {
+				places := []Position{}
+				candidates := []int{}
+				for _, position := range house.Positions() {
+					if grid.GetCell(position.Row, position.Col).IsPossibleValue(value) {
+						places = append(places, position)
+						candidates = append(candidates, singles[newCandidate(position.Row, position.Col, value)])
+					}
+				}
+				for _, id := range groups[house] {
+					if graph.nodes[id].value == value {
+						candidates = append(candidates, id)
+					}
+				}
+				for first := range candidates {
+					for second := first + 1; second < len(candidates); second++ {
+						if coversExactly(graph.nodes[candidates[first]], graph.nodes[candidates[second]], places) {
+							link(graph.strong, candidates[first], candidates[second])
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Link every pair of nodes which cannot both be true
+	linked = map[[2]int]bool{}
+	for first := range graph.nodes {
+		for second := first + 1; second < len(graph.nodes); second++ {
+			a, b := graph.nodes[first], graph.nodes[second]
+			switch {
+			case a.value == b.value && a.seesAllOf(b):
+				link(graph.weak, first, second)
+			case links.cellWeak && a.value != b.value && len(a.positions) == 1 && len(b.positions) == 1 &&
+				a.positions[0] == b.positions[0]:
+				link(graph.weak, first, second)
+			}
+		}
+	}
+	return graph
+}
+
+// coversExactly returns whether the 2 nodes share no Cells and together hold
+// exactly the places.
+func coversExactly(first chainNode, second chainNode, places []Position) bool {
+	if len(first.positions)+len(second.positions) != len(places) {
+		return false
+	}
+	for _, position := range first.positions {
+		if containsPosition(second.positions, position) || !containsPosition(places, position) {
+			return false
+		}
+	}
+	for _, position := range second.positions {
+		if !containsPosition(places, position) {
+			return false
+		}
+	}
+	return true
+}
+
+// chainStrategy finds alternating inference chains (AICs), which start and end
+// with strong links and alternate strong and weak links along the way.  If the
+// first node is false then the last is true, so one of the ends must be true and
+// any candidate which sees both ends is eliminated.  A chain which leads from a
+// candidate back to itself places it.  The kinds of link searched for depend on
+// the technique, from single value X-Chains to grouped AICs.
+type chainStrategy struct {
+	technique string
+}
+
+func (c *chainStrategy) Name() string { return c.technique }
+func (c *chainStrategy) Difficulty() float64 {
+	return map[string]float64{
+		TechniqueXChain:     4.7,
+		TechniqueXYChain:    4.8,
+		TechniqueAIC:        4.9,
+		TechniqueGroupedAIC: 5.1,
+	}[c.technique]
+}
+
+// links returns the kinds of node and link used by the technique.
+func (c *chainStrategy) links() chainLinks {
+	switch c.technique {
+	case TechniqueXChain:
+		return chainLinks{houseStrong: true}
+	case TechniqueXYChain:
+		return chainLinks{cellStrong: true}
+	case TechniqueAIC:
+		return chainLinks{houseStrong: true, cellStrong: true, cellWeak: true}
+	default:
+		return chainLinks{houseStrong: true, cellStrong: true, cellWeak: true, groups: true}
+	}
+}
+
+func (c *chainStrategy) Apply(grid *Grid) []Step {
+	graph := newChainGraph(grid, c.links())
+
+	// Keep the shortest productive chain from any starting node
+	var best *Step
+	bestLength := maxChainNodes + 1
+	for start := range graph.nodes {
+		chain, step := graph.search(grid, start, bestLength-1)
+		if step != nil {
+			step.Technique = c.technique
+			best, bestLength = step, len(chain)
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	applyStep(grid, *best)
+	return []Step{*best}
+}
+
+// search walks breadth first from the start node, assumed false, alternating
+// strong and weak links, and returns the first chain of at most maxLength nodes
+// with 2 or more strong links which makes progress, along with its Step.
+func (g *chainGraph) search(grid *Grid, start int, maxLength int) ([]int, *Step) {
+
+	// Each state is a node which is either false (reached by a weak link, so left
+	// by a strong link), or true (reached by a strong link, so left by a weak link),
+	// indexed as node*2 plus 1 when true
+	parents := make([]int, len(g.nodes)*2)
+	depths := make([]int, len(g.nodes)*2)
+	depths[start*2] = 1
+	queue := []int{start * 2}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if depths[current] >= maxLength {
+			continue
+		}
+		on := current%2 == 1
+		next := g.strong[current/2]
+		if on {
+			next = g.weak[current/2]
+		}
+		for _, node := range next {
+			reached := node * 2
+			if !on {
+				reached++
+			}
+			if depths[reached] > 0 {
+				continue
+			}
+
+			// Recover the chain, skipping nodes which are already in it
+			chain := make([]int, depths[current]+1)
+			chain[len(chain)-1] = node
+			for index, walk := len(chain)-2, current; index >= 0; index, walk = index-1, parents[walk] {
+				chain[index] = walk / 2
+			}
+			if repeatsNodes(chain) {
+				continue
+			}
+			depths[reached] = depths[current] + 1
+			parents[reached] = current
+			queue = append(queue, reached)
+
+			// Check the conclusions of a chain ending with a strong link
+			if !on && len(chain) >= 4 {
+				if step := g.chainStep(grid, chain); step != nil {
+					return chain, step
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// repeatsNodes returns whether any node appears twice in the chain, other than
+// the first node appearing again at the end.
+func repeatsNodes(chain []int) bool {
+	seen := map[int]bool{}
+	for index, node := range chain {
+		if seen[node] && !(index == len(chain)-1 && node == chain[0]) {
+			return true
+		}
+		seen[node] = true
+	}
+	return false
+}
+
+// chainStep returns the Step for the chain, where one of the ends must be true, or
+// nil if it makes no progress.
+func (g *chainGraph) chainStep(grid *Grid, chain []int) *Step {
+	first, last := g.nodes[chain[0]], g.nodes[chain[len(chain)-1]]
+	step := &Step{}
+	switch {
+
+	// A chain from a single candidate back to itself places it
+	case chain[0] == chain[len(chain)-1]:
+		if len(first.positions) == 1 {
+			step.Placements = []Candidate{newCandidate(first.positions[0].Row, first.positions[0].Col, first.value)}
+		}
+
+	// Ends with the same value eliminate it from every Cell which sees them both
+	case first.value == last.value:
+		target := chainNode{value: first.value}
+		for row := 0; row < 9; row++ {
+			for col := 0; col < 9; col++ {
+				target.positions = []Position{{Row: row, Col: col}}
+				if grid.GetCell(row, col).IsPossibleValue(first.value) && target.seesAllOf(first) && target.seesAllOf(last) {
+					step.Eliminations = append(step.Eliminations, newCandidate(row, col, first.value))
+				}
+			}
+		}
+
+	// Ends with different values in the same Cell eliminate its other values
+	case len(first.positions) == 1 && len(last.positions) == 1 && first.positions[0] == last.positions[0]:
+		position := first.positions[0]
+		for _, value := range grid.GetCell(position.Row, position.Col).possibleMask().values() {
+			if value != first.value && value != last.value {
+				step.Eliminations = append(step.Eliminations, newCandidate(position.Row, position.Col, value))
+			}
+		}
+
+	// Ends with different values in Cells which see each other eliminate each
+	// value from the other's Cell
+	case len(first.positions) == 1 && len(last.positions) == 1 && first.positions[0].sees(last.positions[0]):
+		for _, end := range []struct{ node, other chainNode }{{first, last}, {last, first}} {
+			position := end.node.positions[0]
+			if grid.GetCell(position.Row, position.Col).IsPossibleValue(end.other.value) {
+				step.Eliminations = append(step.Eliminations, newCandidate(position.Row, position.Col, end.other.value))
+			}
+		}
+	}
+	if len(step.Placements) == 0 && len(step.Eliminations) == 0 {
+		return nil
+	}
+	for _, node := range chain {
+		for _, position := range g.nodes[node].positions {
+			if !containsPosition(step.Cells, position) {
+				step.Cells = append(step.Cells, position)
+			}
+		}
+	}
+	step.Reason = g.formatChain(chain) + " => " + step.Conclusions()
+	return step
+}
+
+// formatChain returns the chain in Eureka notation, e.g. "(5)r1c2=(5)r1c8-(5)r4c8=(5)r4c2".
+func (g *chainGraph) formatChain(chain []int) string {
+	var builder strings.Builder
+	for index, node := range chain {
+		if index > 0 {
+			builder.WriteString(map[bool]string{true: "=", false: "-"}[index%2 == 1])
+		}
+		builder.WriteString(g.nodes[node].String())
+	}
+	return builder.String()
+}
@@ -0,0 +1,186 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainNode_String(t *testing.T) {
+	assert.Equal(t, "(5)r1c2", chainNode{value: 5, positions: []Position{{0, 1}}}.String())
+	assert.Equal(t, "(5)r1c23", chainNode{value: 5, positions: []Position{{0, 1}, {0, 2}}}.String())
+	assert.Equal(t, "(7)r456c9", chainNode{value: 7, positions: []Position{{3, 8}, {4, 8}, {5, 8}}}.String())
+}
+
+func TestNewChainGraph(t *testing.T) {
+
+	// Confine value 1 in row 1 to a group in box 1 and r1c7, and make r1c7 bivalue
+	grid := NewGrid()
+	confineValue(grid, 1, map[House][]Position{{RowHouse, 0}: {{0, 1}, {0, 2}, {0, 6}}})
+	restrictCandidates(grid, 0, 6, 1, 2)
+
+	// Verify the strong links of the grouped graph from (1)r1c7
+	graph := newChainGraph(grid, chainLinks{houseStrong: true, cellStrong: true, cellWeak: true, groups: true})
+	strong := []string{}
+	for _, id := range graph.strong[graph.find(newCandidate(0, 6, 1))] {
+		strong = append(strong, graph.nodes[id].String())
+	}
+	assert.ElementsMatch(t, []string{"(2)r1c7", "(1)r1c23"}, strong)
+
+	// Verify the ungrouped graph has no grouped nodes nor bivalue links
+	graph = newChainGraph(grid, chainLinks{houseStrong: true})
+	assert.Empty(t, graph.strong[graph.find(newCandidate(0, 6, 1))])
+	for _, node := range graph.nodes {
+		assert.Len(t, node.positions, 1)
+	}
+}
+
+func TestChainStrategy(t *testing.T) {
+
+	// Define The TestCases, confining value 1 to the listed Cells of each House
+	// and restricting the listed Cells to the bivalue candidates
+	testCases := map[string]struct {
+		technique  string
+		difficulty float64
+		links      map[House][]Position
+		bivalues   map[Position][]int
+		eliminated []Candidate
+		reason     string
+	}{
+		"X-Chain": {
+			technique:  TechniqueXChain,
+			difficulty: 4.7,
+			links:      map[House][]Position{{ColHouse, 0}: {{0, 0}, {4, 0}}, {ColHouse, 5}: {{1, 5}, {4, 5}}},
+			eliminated: []Candidate{newCandidate(0, 3, 1), newCandidate(0, 4, 1), newCandidate(1, 1, 1), newCandidate(1, 2, 1)},
+			reason:     "(1)r1c1=(1)r5c1-(1)r5c6=(1)r2c6 => r1c4<>1, r1c5<>1, r2c2<>1, r2c3<>1",
+		},
+		"XY-Chain": {
+			technique:  TechniqueXYChain,
+			difficulty: 4.8,
+			bivalues:   map[Position][]int{{0, 0}: {1, 2}, {0, 4}: {2, 3}, {4, 4}: {1, 3}},
+			eliminated: []Candidate{newCandidate(4, 0, 1)},
+			reason:     "(1)r1c1=(2)r1c1-(2)r1c5=(3)r1c5-(3)r5c5=(1)r5c5 => r5c1<>1",
+		},
+		"AIC": {
+			technique:  TechniqueAIC,
+			difficulty: 4.9,
+			links:      map[House][]Position{{ColHouse, 4}: {{0, 4}, {4, 4}}},
+			bivalues:   map[Position][]int{{0, 0}: {1, 2}, {4, 1}: {1, 2}},
+			eliminated: []Candidate{newCandidate(0, 1, 2), newCandidate(1, 1, 2), newCandidate(2, 1, 2), newCandidate(3, 0, 2), newCandidate(4, 0, 2), newCandidate(5, 0, 2)},
+			reason:     "(2)r1c1=(1)r1c1-(1)r1c5=(1)r5c5-(1)r5c2=(2)r5c2 => r1c2<>2, r2c2<>2, r3c2<>2, r4c1<>2, r5c1<>2, r6c1<>2",
+		},
+		"Grouped AIC": {
+			technique:  TechniqueGroupedAIC,
+			difficulty: 5.1,
+			links:      map[House][]Position{{RowHouse, 0}: {{0, 1}, {0, 2}, {0, 6}}, {ColHouse, 0}: {{2, 0}, {6, 0}}},
+			eliminated: []Candidate{newCandidate(6, 6, 1)},
+			reason:     "(1)r1c7=(1)r1c23-(1)r3c1=(1)r7c1 => r7c7<>1",
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			strategy := &chainStrategy{technique: testCase.technique}
+			assert.Equal(t, testCase.technique, strategy.Name())
+			assert.Equal(t, testCase.difficulty, strategy.Difficulty())
+
+			// Create the links in an empty Grid
+			grid := NewGrid()
+			confineValue(grid, 1, testCase.links)
+			for position, values := range testCase.bivalues {
+				restrictCandidates(grid, position.Row, position.Col, values...)
+			}
+
+			// Verify the chain is found and its conclusions applied
+			steps := strategy.Apply(grid)
+			assert.Len(t, steps, 1)
+			assert.Equal(t, testCase.technique, steps[0].Technique)
+			assert.Equal(t, testCase.reason, steps[0].Reason)
+			assert.ElementsMatch(t, testCase.eliminated, steps[0].Eliminations)
+		})
+	}
+}
+
+func TestChainStrategy_Placement(t *testing.T) {
+
+	// Bivalue Cells r1c1 {1,2}, r1c5 {2,3}, r5c5 {3,1} and r5c1 {1,2}, where value 1
+	// in column 1 can only go in r1c1 or r5c1: if r1c1 is not 1 then it is 2, so r1c5
+	// is 3, r5c5 is 1, r5c1 is 2, and column 1 forces r1c1 to be 1 after all
+	grid := NewGrid()
+	confineValue(grid, 1, map[House][]Position{{ColHouse, 0}: {{0, 0}, {4, 0}}})
+	restrictCandidates(grid, 0, 0, 1, 2)
+	restrictCandidates(grid, 0, 4, 2, 3)
+	restrictCandidates(grid, 4, 4, 1, 3)
+	restrictCandidates(grid, 4, 0, 1, 2)
+
+	// Verify the chain places value 1 in r1c1
+	graph := newChainGraph(grid, (&chainStrategy{technique: TechniqueAIC}).links())
+	chain := []int{
+		graph.find(newCandidate(0, 0, 1)), graph.find(newCandidate(0, 0, 2)),
+		graph.find(newCandidate(0, 4, 2)), graph.find(newCandidate(0, 4, 3)),
+		graph.find(newCandidate(4, 4, 3)), graph.find(newCandidate(4, 4, 1)),
+		graph.find(newCandidate(4, 0, 1)), graph.find(newCandidate(0, 0, 1)),
+	}
+	step := graph.chainStep(grid, chain)
+	assert.NotNil(t, step)
+	assert.Equal(t, []Candidate{newCandidate(0, 0, 1)}, step.Placements)
+	assert.Equal(t, "(1)r1c1=(2)r1c1-(2)r1c5=(3)r1c5-(3)r5c5=(1)r5c5-(1)r5c1=(1)r1c1 => r1c1=1", step.Reason)
+}
+
+func TestRepeatsNodes(t *testing.T) {
+	assert.False(t, repeatsNodes([]int{1, 2, 3, 4}))
+	assert.False(t, repeatsNodes([]int{1, 2, 3, 1}))
+	assert.True(t, repeatsNodes([]int{1, 2, 1, 4}))
+}
+
+func TestChainStrategy_Puzzle(t *testing.T) {
+
+	// Define The TestCases
+	testCases := map[string]struct {
+		technique string
+		puzzle    string
+		solution  string
+	}{
+		"X-Chain": {
+			technique: TechniqueXChain,
+			puzzle:    "....76....124....5....1..8..7..32....29..86....86....34....1.56......31....36...2",
+			solution:  "985276134712483965634915287576132498329548671148697523493721856267854319851369742",
+		},
+		"XY-Chain": {
+			technique: TechniqueXYChain,
+			puzzle:    ".2..13.......7..965......1........7..946...5..8...76....3.8.......59.2..249......",
+			solution:  "926813745431275896578964312612359478794628153385147629153482967867591234249736581",
+		},
+		"AIC": {
+			technique: TechniqueAIC,
+			puzzle:    "...6.....39.....41......3.......1...9....4.7.68....2...6..8..5..4.7....9.2.4.976.",
+			solution:  "812643597395278641476915382257861934931524876684397215769182453548736129123459768",
+		},
+		"Grouped AIC": {
+			technique: TechniqueGroupedAIC,
+			puzzle:    "...6.....39.....41......3.......1...9....4.7.68....2...6..8..5..4.7....9.2.4.976.",
+			solution:  "812643597395278641476915382257861934931524876684397215769182453548736129123459768",
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			steps := testCollectSteps(testGridFromString(testCase.puzzle), &chainStrategy{technique: testCase.technique})
+			assert.NotEmpty(t, steps)
+			assertValidSteps(t, testCase.solution, steps)
+		})
+	}
+}
+
+// find returns the index of the single node for the Candidate, or -1 if it is not
+// possible.
+func (g *chainGraph) find(candidate Candidate) int {
+	for id, node := range g.nodes {
+		if node.value == candidate.Value && len(node.positions) == 1 && node.positions[0] == candidate.Position {
+			return id
+		}
+	}
+	return -1
+}
@@ -0,0 +1,271 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Names of the coloring techniques, as reported in a SolveResult.
+const (
+	TechniqueSimpleColoring = "Simple Coloring" // Color trap or color wrap within a single chain of strong links
+	TechniqueMultiColoring  = "Multi-Coloring"  // Weak links between the colors of 2 chains of strong links
+)
+
+// colorCluster is a connected chain of strong links (conjugate pairs) for a value,
+// with its Cells split into 2 colors which alternate along every link.  Exactly
+// one of the colors holds the value.
+type colorCluster struct {
+	colors [2][]Position
+}
+
+// String returns the colors of the cluster in the form "{r1c2,r4c8}/{r1c8,r5c2}".
+func (c colorCluster) String() string {
+	return fmt.Sprintf("{%s}/{%s}", formatPositions(c.colors[0]), formatPositions(c.colors[1]))
+}
+
+// contains returns whether the Position is in either color of the cluster.
+func (c colorCluster) contains(position Position) bool {
+	return containsPosition(c.colors[0], position) || containsPosition(c.colors[1], position)
+}
+
+// cells returns the Cells of both colors of the cluster.
+func (c colorCluster) cells() []Position {
+	return append(append([]Position{}, c.colors[0]...), c.colors[1]...)
+}
+
+// colorClusters builds the graph of strong links for the value across all Houses,
+// and returns each connected chain two-colored, in the order their first Cell
+// appears in the Grid.  The first Cell of each chain is given the first color.
+func colorClusters(grid *Grid, value int) []colorCluster {
+
+	// Build the conjugate pair graph
+	neighbours := map[Position][]Position{}
+	for _, link := range strongLinks(grid, value) {
+		neighbours[link.ends[0]] = append(neighbours[link.ends[0]], link.ends[1])
+		neighbours[link.ends[1]] = append(neighbours[link.ends[1]], link.ends[0])
+	}
+
+	// Two-color each connected chain, walking it breadth first
+	clusters := []colorCluster{}
+	colored := map[Position]bool{}
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			start := Position{Row: row, Col: col}
+			if len(neighbours[start]) == 0 || colored[start] {
+				continue
+			}
+			cluster := colorCluster{}
+			colors := map[Position]int{start: 0}
+			queue := []Position{start}
+			colored[start] = true
+			for len(queue) > 0 {
+				position := queue[0]
+				queue = queue[1:]
+				cluster.colors[colors[position]] = append(cluster.colors[colors[position]], position)
+				for _, neighbour := range neighbours[position] {
+					if !colored[neighbour] {
+						colored[neighbour] = true
+						colors[neighbour] = 1 - colors[position]
+						queue = append(queue, neighbour)
+					}
+				}
+			}
+			for _, positions := range cluster.colors {
+				sort.Slice(positions, func(i, j int) bool {
+					return positions[i].Row*9+positions[i].Col < positions[j].Row*9+positions[j].Col
+				})
+			}
+			clusters = append(clusters, cluster)
+		}
+	}
+	return clusters
+}
+
+// coloringStrategy eliminates a value by two-coloring each chain of strong links.
+// Simple coloring applies 2 rules to a single chain, the "color wrap" where 2 Cells
+// of one color see each other so that color cannot hold the value, and the "color
+// trap" where a Cell outside the chain sees both colors so cannot hold the value.
+// Multi-coloring applies 2 rules to pairs of chains when a color of one sees a color
+// of the other; if it sees both colors of the other it cannot hold the value,
+// otherwise one of the 2 opposite colors must hold it, so it is eliminated from
+// Cells which see both of them.
+type coloringStrategy struct {
+	multi bool // Whether to look between chains rather than within them
+}
+
+func (c *coloringStrategy) Name() string {
+	if c.multi {
+		return TechniqueMultiColoring
+	}
+	return TechniqueSimpleColoring
+}
+
+func (c *coloringStrategy) Difficulty() float64 {
+	if c.multi {
+		return 4.0
+	}
+	return 3.6
+}
+
+func (c *coloringStrategy) Apply(grid *Grid) []Step {
+	for value := 1; value <= 9; value++ {
+		clusters := colorClusters(grid, value)
+		var step *Step
+		if c.multi {
+			step = multiColoringStep(grid, value, clusters)
+		} else {
+			step = simpleColoringStep(grid, value, clusters)
+		}
+		if step != nil {
+			applyStep(grid, *step)
+			return []Step{*step}
+		}
+	}
+	return nil
+}
+
+// simpleColoringStep returns the Step for the first color wrap or color trap in
+// the clusters of the value, or nil if there are none which make progress.
+func simpleColoringStep(grid *Grid, value int, clusters []colorCluster) *Step {
+	for _, cluster := range clusters {
+
+		// Color wrap, where 2 Cells of the same color share a House
+		for color := 0; color < 2; color++ {
+			positions := cluster.colors[color]
+			for first := range positions {
+				for second := first + 1; second < len(positions); second++ {
+					if !positions[first].sees(positions[second]) {
+						continue
+					}
+					return &Step{
+						Technique:    TechniqueSimpleColoring,
+						Eliminations: candidatesOf(positions, value),
+						Cells:        cluster.cells(),
+						Houses:       []House{sharedHouse(positions[first], positions[second])},
+						Reason: fmt.Sprintf("Value %d chain %s has color {%s} twice in %s",
+							value, cluster, formatPositions(positions), sharedHouse(positions[first], positions[second])),
+					}
+				}
+			}
+		}
+
+		// Color trap, where a Cell outside the chain sees both colors
+		eliminations := eliminateSeenByColors(grid, value, cluster.colors[0], cluster.colors[1], cluster)
+		if len(eliminations) > 0 {
+			return &Step{
+				Technique:    TechniqueSimpleColoring,
+				Eliminations: eliminations,
+				Cells:        cluster.cells(),
+				Reason:       fmt.Sprintf("Value %d chain %s traps cells seeing both colors", value, cluster),
+			}
+		}
+	}
+	return nil
+}
+
+// multiColoringStep returns the Step for the first pair of clusters of the value
+// where a color of one sees a color of the other and makes progress, or nil.
+func multiColoringStep(grid *Grid, value int, clusters []colorCluster) *Step {
+	for first := range clusters {
+		for second := range clusters {
+			if first == second {
+				continue
+			}
+			a, b := clusters[first], clusters[second]
+			for colorA := 0; colorA < 2; colorA++ {
+				for colorB := 0; colorB < 2; colorB++ {
+					if !anySees(a.colors[colorA], b.colors[colorB]) {
+						continue
+					}
+
+					// A color seeing both colors of the other chain cannot hold the value
+					if anySees(a.colors[colorA], b.colors[1-colorB]) {
+						return &Step{
+							Technique:    TechniqueMultiColoring,
+							Eliminations: candidatesOf(a.colors[colorA], value),
+							Cells:        append(a.cells(), b.cells()...),
+							Reason: fmt.Sprintf("Value %d chains %s and %s, where color {%s} sees both colors of the second",
+								value, a, b, formatPositions(a.colors[colorA])),
+						}
+					}
+
+					// Otherwise one of the opposite colors must hold the value
+					eliminations := eliminateSeenByColors(grid, value, a.colors[1-colorA], b.colors[1-colorB], a, b)
+					if len(eliminations) > 0 {
+						return &Step{
+							Technique:    TechniqueMultiColoring,
+							Eliminations: eliminations,
+							Cells:        append(a.cells(), b.cells()...),
+							Reason: fmt.Sprintf("Value %d chains %s and %s, where color {%s} sees color {%s}, trap cells seeing both {%s} and {%s}",
+								value, a, b, formatPositions(a.colors[colorA]), formatPositions(b.colors[colorB]),
+								formatPositions(a.colors[1-colorA]), formatPositions(b.colors[1-colorB])),
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// eliminateSeenByColors returns the eliminations of the value from every Cell
+// outside the clusters which sees a Cell of both colors, where it is still possible.
+func eliminateSeenByColors(grid *Grid, value int, first []Position, second []Position, clusters ...colorCluster) []Candidate {
+	eliminations := []Candidate{}
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			position := Position{Row: row, Col: col}
+			if !grid.GetCell(row, col).IsPossibleValue(value) || inClusters(clusters, position) {
+				continue
+			}
+			if anySees([]Position{position}, first) && anySees([]Position{position}, second) {
+				eliminations = append(eliminations, newCandidate(row, col, value))
+			}
+		}
+	}
+	return eliminations
+}
+
+// anySees returns whether any of the first Positions sees any of the second.
+func anySees(first []Position, second []Position) bool {
+	for _, a := range first {
+		for _, b := range second {
+			if a.sees(b) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inClusters returns whether the Position is in any of the clusters.
+func inClusters(clusters []colorCluster, position Position) bool {
+	for _, cluster := range clusters {
+		if cluster.contains(position) {
+			return true
+		}
+	}
+	return false
+}
+
+// candidatesOf returns the Candidates for the value in each of the Positions.
+func candidatesOf(positions []Position, value int) []Candidate {
+	candidates := make([]Candidate, len(positions))
+	for index, position := range positions {
+		candidates[index] = newCandidate(position.Row, position.Col, value)
+	}
+	return candidates
+}
+
+// sharedHouse returns a House containing both Positions, preferring the Row, then
+// the Column, then the Group.
+func sharedHouse(first Position, second Position) House {
+	switch {
+	case first.Row == second.Row:
+		return House{Kind: RowHouse, Index: first.Row}
+	case first.Col == second.Col:
+		return House{Kind: ColHouse, Index: first.Col}
+	default:
+		return House{Kind: GroupHouse, Index: (first.Row/3)*3 + first.Col/3}
+	}
+}
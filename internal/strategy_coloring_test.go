@@ -0,0 +1,132 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColorClusters(t *testing.T) {
+
+	// Join strong links in columns 1 and 6 through row 5, and a separate one in column 9
+	grid := NewGrid()
+	confineValue(grid, 1, map[House][]Position{
+		{ColHouse, 0}: {{0, 0}, {4, 0}},
+		{RowHouse, 4}: {{4, 0}, {4, 5}},
+		{ColHouse, 5}: {{1, 5}, {4, 5}},
+		{ColHouse, 8}: {{6, 8}, {8, 8}},
+	})
+
+	// Verify the clusters alternate colors along each chain
+	clusters := colorClusters(grid, 1)
+	assert.Len(t, clusters, 2)
+	assert.Equal(t, "{r1c1,r5c6}/{r2c6,r5c1}", clusters[0].String())
+	assert.Equal(t, "{r7c9}/{r9c9}", clusters[1].String())
+	assert.True(t, clusters[0].contains(Position{4, 0}))
+	assert.False(t, clusters[0].contains(Position{6, 8}))
+	assert.Empty(t, colorClusters(grid, 2))
+}
+
+func TestColoringStrategy(t *testing.T) {
+
+	// Define The TestCases, confining value 1 to the listed Cells of each House
+	testCases := map[string]struct {
+		multi      bool
+		links      map[House][]Position
+		eliminated []Candidate
+		reason     string
+	}{
+		"Color Wrap": {
+			links: map[House][]Position{
+				{RowHouse, 0}:   {{0, 0}, {0, 4}},
+				{ColHouse, 4}:   {{0, 4}, {4, 4}},
+				{GroupHouse, 4}: {{4, 4}, {3, 3}},
+				{RowHouse, 3}:   {{3, 3}, {3, 0}},
+			},
+			eliminated: []Candidate{newCandidate(0, 0, 1), newCandidate(3, 0, 1), newCandidate(4, 4, 1)},
+			reason:     "Value 1 chain {r1c1,r4c1,r5c5}/{r1c5,r4c4} has color {r1c1,r4c1,r5c5} twice in column 1",
+		},
+		"Color Trap": {
+			links: map[House][]Position{
+				{ColHouse, 0}: {{0, 0}, {4, 0}},
+				{RowHouse, 4}: {{4, 0}, {4, 5}},
+				{ColHouse, 5}: {{1, 5}, {4, 5}},
+			},
+			eliminated: []Candidate{newCandidate(0, 3, 1), newCandidate(0, 4, 1), newCandidate(1, 1, 1), newCandidate(1, 2, 1)},
+			reason:     "Value 1 chain {r1c1,r5c6}/{r2c6,r5c1} traps cells seeing both colors",
+		},
+		"Multi-Color Trap": {
+			multi: true,
+			links: map[House][]Position{
+				{ColHouse, 0}: {{0, 0}, {4, 0}},
+				{ColHouse, 5}: {{1, 5}, {4, 5}},
+			},
+			eliminated: []Candidate{newCandidate(0, 3, 1), newCandidate(0, 4, 1), newCandidate(1, 1, 1), newCandidate(1, 2, 1)},
+			reason:     "Value 1 chains {r1c1}/{r5c1} and {r2c6}/{r5c6}, where color {r5c1} sees color {r5c6}, trap cells seeing both {r1c1} and {r2c6}",
+		},
+		"Multi-Color Wrap": {
+			multi: true,
+			links: map[House][]Position{
+				{ColHouse, 0}: {{0, 0}, {4, 0}},
+				{ColHouse, 1}: {{4, 1}, {5, 1}},
+			},
+			eliminated: []Candidate{newCandidate(4, 0, 1)},
+			reason:     "Value 1 chains {r1c1}/{r5c1} and {r5c2}/{r6c2}, where color {r5c1} sees both colors of the second",
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			strategy := &coloringStrategy{multi: testCase.multi}
+
+			// Create the chains in an empty Grid
+			grid := NewGrid()
+			confineValue(grid, 1, testCase.links)
+
+			// Verify the coloring is found and the value eliminated
+			steps := strategy.Apply(grid)
+			assert.Len(t, steps, 1)
+			assert.Equal(t, strategy.Name(), steps[0].Technique)
+			assert.Equal(t, testCase.reason, steps[0].Reason)
+			assert.ElementsMatch(t, testCase.eliminated, steps[0].Eliminations)
+		})
+	}
+}
+
+func TestColoringStrategy_NameDifficulty(t *testing.T) {
+	assert.Equal(t, TechniqueSimpleColoring, (&coloringStrategy{}).Name())
+	assert.Equal(t, 3.6, (&coloringStrategy{}).Difficulty())
+	assert.Equal(t, TechniqueMultiColoring, (&coloringStrategy{multi: true}).Name())
+	assert.Equal(t, 4.0, (&coloringStrategy{multi: true}).Difficulty())
+}
+
+func TestColoringStrategy_Puzzle(t *testing.T) {
+
+	// Define The TestCases
+	testCases := map[string]struct {
+		strategy *coloringStrategy
+		puzzle   string
+		solution string
+	}{
+		"Simple Coloring": {
+			strategy: &coloringStrategy{},
+			puzzle:   "...6.....39.....41......3.......1...9....4.7.68....2...6..8..5..4.7....9.2.4.976.",
+			solution: "812643597395278641476915382257861934931524876684397215769182453548736129123459768",
+		},
+		"Multi-Coloring": {
+			strategy: &coloringStrategy{multi: true},
+			puzzle:   "....76....124....5....1..8..7..32....29..86....86....34....1.56......31....36...2",
+			solution: "985276134712483965634915287576132498329548671148697523493721856267854319851369742",
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			steps := testCollectSteps(testGridFromString(testCase.puzzle), testCase.strategy)
+			assert.NotEmpty(t, steps)
+			assertValidSteps(t, testCase.solution, steps)
+		})
+	}
+}
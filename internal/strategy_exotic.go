@@ -0,0 +1,555 @@
+package internal
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// Names of the exotic techniques, as reported in a SolveResult.
+const (
+	TechniqueSKLoop       = "SK-Loop"                 // A loop of 16 Cells around 4 boxes locking a value set into each link
+	TechniqueJuniorExocet = "Junior Exocet"           // 2 base Cells whose values must repeat in 2 target Cells
+	TechniqueMSLS         = "Multi-Sector Locked Set" // Cells where rows cross columns locked by the values of each line
+)
+
+// maxMSLSLines limits the number of rows, and of columns, in a Multi-Sector Locked Set.
+const maxMSLSLines = 4
+
+// skLoopStrategy looks for 4 pivot Cells at the corners of a rectangle, each in a
+// different box, where the 2 other Cells of each pivot's row and column within
+// its box form a loop of 8 pairs.  Adjacent pairs share a row, a column, or a box,
+// which links them.  Each value of a pair is locked into one of its 2 links, which
+// can hold the value at most once, and when the links hold 16 values between them,
+// as many as the Cells of the loop, each link must place all its values in its 4
+// Cells, so they are eliminated from the rest of the link's House.
+type skLoopStrategy struct{}
+
+func (s *skLoopStrategy) Name() string        { return TechniqueSKLoop }
+func (s *skLoopStrategy) Difficulty() float64 { return 6.0 }
+func (s *skLoopStrategy) Tier() StrategyTier  { return ExoticTier }
+func (s *skLoopStrategy) Apply(grid *Grid) []Step {
+	for firstRow := 0; firstRow < 9; firstRow++ {
+		for secondRow := (firstRow/3 + 1) * 3; secondRow < 9; secondRow++ {
+			for firstCol := 0; firstCol < 9; firstCol++ {
+				for secondCol := (firstCol/3 + 1) * 3; secondCol < 9; secondCol++ {
+					step := skLoopStep(grid, [2]int{firstRow, secondRow}, [2]int{firstCol, secondCol})
+					if step != nil {
+						applyStep(grid, *step)
+						return []Step{*step}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// skLoopStep returns the Step for the SK-Loop around the pivots where the rows
+// cross the columns, or nil if there is none or it makes no progress.
+func skLoopStep(grid *Grid, rows [2]int, cols [2]int) *Step {
+
+	// Walk the pairs around the loop, along the first row, down the second column,
+	// back along the second row and up the first column, along with the House
+	// linking each pair to the next
+	pivots := [4]Position{{rows[0], cols[0]}, {rows[0], cols[1]}, {rows[1], cols[1]}, {rows[1], cols[0]}}
+	pairs := [8][]Position{
+		boxPair(pivots[0], RowHouse), boxPair(pivots[1], RowHouse), boxPair(pivots[1], ColHouse), boxPair(pivots[2], ColHouse),
+		boxPair(pivots[2], RowHouse), boxPair(pivots[3], RowHouse), boxPair(pivots[3], ColHouse), boxPair(pivots[0], ColHouse),
+	}
+	links := [8]House{
+		{Kind: RowHouse, Index: rows[0]}, boxOf(pivots[1]), {Kind: ColHouse, Index: cols[1]}, boxOf(pivots[2]),
+		{Kind: RowHouse, Index: rows[1]}, boxOf(pivots[3]), {Kind: ColHouse, Index: cols[0]}, boxOf(pivots[0]),
+	}
+
+	// Every Cell of the loop must be unsolved
+	masks := [8]candidateMask{}
+	for index, pair := range pairs {
+		for _, position := range pair {
+			cell := grid.GetCell(position.Row, position.Col)
+			if cell.GetValue() != 0 {
+				return nil
+			}
+			masks[index] |= cell.possibleMask()
+		}
+	}
+
+	// Each value must be locked into the fewest links which cover every pair where
+	// it is possible, and between them the links must hold as many values as Cells
+	values := [8]candidateMask{}
+	total := 0
+	for value := 1; value <= 9; value++ {
+		links := skLoopCover(masks, value)
+		for index := range values {
+			if links&(1<<index) != 0 {
+				values[index] |= maskOf(value)
+				total++
+			}
+		}
+	}
+	if total != 16 {
+		return nil
+	}
+
+	// Eliminate the values of each link from the rest of its House
+	eliminations := []Candidate{}
+	eliminated := map[Candidate]bool{}
+	locked := make([]string, len(links))
+	for index, link := range links {
+		excluded := append(append([]Position{}, pairs[index]...), pairs[(index+1)%8]...)
+		for _, elimination := range eliminateFromHouse(grid, link, excluded, values[index]) {
+			if !eliminated[elimination] {
+				eliminated[elimination] = true
+				eliminations = append(eliminations, elimination)
+			}
+		}
+		locked[index] = fmt.Sprintf("%s into %s", values[index], link)
+	}
+	if len(eliminations) == 0 {
+		return nil
+	}
+	cells := []Position{}
+	for _, pair := range pairs {
+		cells = append(cells, pair...)
+	}
+	return &Step{
+		Technique:    TechniqueSKLoop,
+		Eliminations: eliminations,
+		Cells:        cells,
+		Houses:       links[:],
+		Reason:       fmt.Sprintf("SK-Loop around pivots %s locks %s", formatPositions(pivots[:]), strings.Join(locked, ", ")),
+	}
+}
+
+// skLoopCover returns the smallest set of links, as a bit per link, where each
+// pair of the loop with the value possible is next to one of the links.
+func skLoopCover(masks [8]candidateMask, value int) int {
+	best, bestCount := 0, 9
+	for links := 0; links < 1<<8; links++ {
+		count := bits.OnesCount(uint(links))
+		if count >= bestCount {
+			continue
+		}
+		covered := true
+		for index, mask := range masks {
+			if mask.has(value) && links&(1<<index) == 0 && links&(1<<((index+7)%8)) == 0 {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			best, bestCount = links, count
+		}
+	}
+	return best
+}
+
+// boxPair returns the 2 other Cells of the pivot's box in its row or column.
+func boxPair(pivot Position, kind HouseKind) []Position {
+	pair := []Position{}
+	for _, position := range boxOf(pivot).Positions() {
+		if position != pivot && ((kind == RowHouse && position.Row == pivot.Row) || (kind == ColHouse && position.Col == pivot.Col)) {
+			pair = append(pair, position)
+		}
+	}
+	return pair
+}
+
+// boxOf returns the Group (box) containing the Position.
+func boxOf(position Position) House {
+	return House{Kind: GroupHouse, Index: (position.Row/3)*3 + position.Col/3}
+}
+
+// juniorExocetStrategy looks for 2 unsolved base Cells in a box/line intersection
+// with 3-4 possible values between them, and 2 unsolved target Cells in the other
+// 2 boxes of the base's band, each on one of the 2 other lines of the band.  The
+// companion Cells, on each target's cross line and the other target's line, must
+// not hold any base value, and each base value must be confined to 2 Houses
+// within the cross lines of the targets and the base box's third cross line
+// outside the band.  Each base value placed in the base must then also be placed
+// in a target, so the targets hold the same 2 values as the base.  The targets
+// lose every value other than the base values, and the base loses any base value
+// possible in neither target.
+type juniorExocetStrategy struct{}
+
+func (j *juniorExocetStrategy) Name() string        { return TechniqueJuniorExocet }
+func (j *juniorExocetStrategy) Difficulty() float64 { return 6.2 }
+func (j *juniorExocetStrategy) Tier() StrategyTier  { return ExoticTier }
+func (j *juniorExocetStrategy) Apply(grid *Grid) []Step {
+	for _, kind := range []HouseKind{RowHouse, ColHouse} {
+		if step := findJuniorExocet(grid, kind); step != nil {
+			applyStep(grid, *step)
+			return []Step{*step}
+		}
+	}
+	return nil
+}
+
+// findJuniorExocet returns the Step for the first Junior Exocet with its base in
+// a line of the kind which makes progress, or nil.
+func findJuniorExocet(grid *Grid, kind HouseKind) *Step {
+	for baseLine := 0; baseLine < 9; baseLine++ {
+		band := (baseLine / 3) * 3
+		otherLines := []int{}
+		for line := band; line < band+3; line++ {
+			if line != baseLine {
+				otherLines = append(otherLines, line)
+			}
+		}
+		for block := 0; block < 3; block++ {
+			for third := block * 3; third < block*3+3; third++ {
+
+				// Take the base from the 2 Cells of the intersection other than the third
+				base := []Position{}
+				for cross := block * 3; cross < block*3+3; cross++ {
+					if cross != third {
+						base = append(base, fishPosition(kind, baseLine, cross))
+					}
+				}
+				values := candidateMask(0)
+				for _, position := range base {
+					values |= grid.GetCell(position.Row, position.Col).possibleMask()
+				}
+				if grid.GetCell(base[0].Row, base[0].Col).GetValue() != 0 || grid.GetCell(base[1].Row, base[1].Col).GetValue() != 0 ||
+					values.count() < 3 || values.count() > 4 {
+					continue
+				}
+
+				// Try a target in each of the other 2 blocks, on different lines
+				blocks := []int{(block + 1) % 3, (block + 2) % 3}
+				for _, lines := range [][2]int{{otherLines[0], otherLines[1]}, {otherLines[1], otherLines[0]}} {
+					for first := blocks[0] * 3; first < blocks[0]*3+3; first++ {
+						for second := blocks[1] * 3; second < blocks[1]*3+3; second++ {
+							exocet := juniorExocet{
+								kind:    kind,
+								band:    band,
+								base:    base,
+								values:  values,
+								lines:   lines,
+								crosses: [3]int{first, second, third},
+							}
+							if step := exocet.step(grid); step != nil {
+								return step
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// juniorExocet is a candidate Junior Exocet pattern, with its base and target
+// Cells described by lines of the kind and the perpendicular cross lines.
+type juniorExocet struct {
+	kind    HouseKind
+	band    int           // The first line of the band holding the base and targets
+	base    []Position    // The base Cells
+	values  candidateMask // The possible values of the base Cells
+	lines   [2]int        // The lines of the first and second targets
+	crosses [3]int        // The cross lines of the first and second targets, and the base box's third cross line
+}
+
+// step returns the Step for the Junior Exocet, or nil if it is not valid or
+// makes no progress.
+func (e *juniorExocet) step(grid *Grid) *Step {
+
+	// Both targets must be unsolved, with at least one base value
+	targets := []Position{fishPosition(e.kind, e.lines[0], e.crosses[0]), fishPosition(e.kind, e.lines[1], e.crosses[1])}
+	for _, target := range targets {
+		cell := grid.GetCell(target.Row, target.Col)
+		if cell.GetValue() != 0 || cell.possibleMask()&e.values == 0 {
+			return nil
+		}
+	}
+
+	// Neither companion may hold a base value
+	companions := []Position{fishPosition(e.kind, e.lines[1], e.crosses[0]), fishPosition(e.kind, e.lines[0], e.crosses[1])}
+	for _, companion := range companions {
+		if heldValues(grid, companion)&e.values != 0 {
+			return nil
+		}
+	}
+
+	// Each base value must be confined to 2 Houses in the cross lines outside the band
+	for _, value := range e.values.values() {
+		positions := []Position{}
+		for _, cross := range e.crosses {
+			for line := 0; line < 9; line++ {
+				position := fishPosition(e.kind, line, cross)
+				if (line < e.band || line >= e.band+3) && heldValues(grid, position).has(value) {
+					positions = append(positions, position)
+				}
+			}
+		}
+		if !coveredByTwoHouses(positions) {
+			return nil
+		}
+	}
+
+	// The targets lose their other values, and the base any value neither target holds
+	eliminations := []Candidate{}
+	targetValues := candidateMask(0)
+	for _, target := range targets {
+		possible := grid.GetCell(target.Row, target.Col).possibleMask()
+		targetValues |= possible
+		for _, value := range (possible &^ e.values).values() {
+			eliminations = append(eliminations, newCandidate(target.Row, target.Col, value))
+		}
+	}
+	for _, position := range e.base {
+		for _, value := range (grid.GetCell(position.Row, position.Col).possibleMask() &^ targetValues).values() {
+			eliminations = append(eliminations, newCandidate(position.Row, position.Col, value))
+		}
+	}
+	if len(eliminations) == 0 {
+		return nil
+	}
+	return &Step{
+		Technique:    TechniqueJuniorExocet,
+		Eliminations: eliminations,
+		Cells:        append(append([]Position{}, e.base...), targets...),
+		Houses:       []House{{Kind: e.kind, Index: e.lines[0]}, {Kind: e.kind, Index: e.lines[1]}},
+		Reason: fmt.Sprintf("Base cells %s %s with target cells %s, whose companions %s hold no base value, must repeat the base values in the targets",
+			formatPositions(e.base), e.values, formatPositions(targets), formatPositions(companions)),
+	}
+}
+
+// heldValues returns the values possible in the Cell at the Position, or its
+// value if it is solved.
+func heldValues(grid *Grid, position Position) candidateMask {
+	cell := grid.GetCell(position.Row, position.Col)
+	if value := cell.GetValue(); value != 0 {
+		return maskOf(value)
+	}
+	return cell.possibleMask()
+}
+
+// coveredByTwoHouses returns whether the Positions all lie within 2 rows or
+// columns, so at most 2 of them can hold the same value.
+func coveredByTwoHouses(positions []Position) bool {
+	if len(positions) <= 2 {
+		return true
+	}
+	houses := []House{}
+	for _, position := range positions {
+		houses = append(houses, House{Kind: RowHouse, Index: position.Row}, House{Kind: ColHouse, Index: position.Col})
+	}
+	for first := range houses {
+		for second := first + 1; second < len(houses); second++ {
+			covered := true
+			for _, position := range positions {
+				if !houses[first].contains(position) && !houses[second].contains(position) {
+					covered = false
+					break
+				}
+			}
+			if covered {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mslsStrategy looks for Multi-Sector Locked Sets, where the unsolved Cells in
+// which a set of rows crosses a set of columns can each only hold a value locked
+// to its row or to its column.  Each value must be locked to a set of the lines
+// covering every Cell where it is possible, and when the fewest lines covering
+// each value add up to the number of Cells, each line must place all its locked
+// values in the Cells.  The locked values are then eliminated from the rest of
+// each line.
+type mslsStrategy struct{}
+
+func (m *mslsStrategy) Name() string        { return TechniqueMSLS }
+func (m *mslsStrategy) Difficulty() float64 { return 6.4 }
+func (m *mslsStrategy) Tier() StrategyTier  { return ExoticTier }
+func (m *mslsStrategy) Apply(grid *Grid) []Step {
+
+	// Read the possible values once, as every combination of lines is tried
+	possible := [9][9]candidateMask{}
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			possible[row][col] = grid.GetCell(row, col).possibleMask()
+		}
+	}
+	var step *Step
+	for rowCount := 2; rowCount <= maxMSLSLines && step == nil; rowCount++ {
+		for colCount := 2; colCount <= maxMSLSLines && step == nil; colCount++ {
+			combinations(9, rowCount, func(rows []int) bool {
+				return combinations(9, colCount, func(cols []int) bool {
+					step = mslsStep(&possible, rows, cols)
+					return step != nil
+				})
+			})
+		}
+	}
+	if step == nil {
+		return nil
+	}
+	applyStep(grid, *step)
+	return []Step{*step}
+}
+
+// mslsStep returns the Step for the Multi-Sector Locked Set where the rows cross
+// the columns, given the possible values of each Cell, or nil if there is none or
+// it makes no progress.
+func mslsStep(possible *[9][9]candidateMask, rows []int, cols []int) *Step {
+
+	// Find the fewest lines covering the Cells where each value is possible, with
+	// the Cells as a bit per column index for each row index
+	cells := []Position{}
+	edges := [10][maxMSLSLines]uint16{}
+	for rowIndex, row := range rows {
+		for colIndex, col := range cols {
+			if possible[row][col] == 0 {
+				continue // Solved
+			}
+			cells = append(cells, Position{Row: row, Col: col})
+			for _, value := range possible[row][col].values() {
+				edges[value][rowIndex] |= 1 << colIndex
+			}
+		}
+	}
+	if len(cells) == 0 {
+		return nil
+	}
+	covered := 0
+	rowValues := [maxMSLSLines]candidateMask{}
+	colValues := [maxMSLSLines]candidateMask{}
+	for value := 1; value <= 9; value++ {
+		coverRows, coverCols := minimumCover(edges[value][:len(rows)])
+		for index := 0; index < maxMSLSLines; index++ {
+			if coverRows&(1<<index) != 0 {
+				rowValues[index] |= maskOf(value)
+			}
+			if coverCols&(1<<index) != 0 {
+				colValues[index] |= maskOf(value)
+			}
+		}
+		covered += bits.OnesCount16(coverRows) + bits.OnesCount16(coverCols)
+		if covered > len(cells) {
+			return nil
+		}
+	}
+	if covered != len(cells) {
+		return nil
+	}
+
+	// Eliminate the locked values from the rest of each line
+	eliminations := []Candidate{}
+	locked := []string{}
+	for rowIndex, row := range rows {
+		for col := 0; col < 9; col++ {
+			if !containsLine(cols, col) {
+				for _, value := range (possible[row][col] & rowValues[rowIndex]).values() {
+					eliminations = append(eliminations, newCandidate(row, col, value))
+				}
+			}
+		}
+		if rowValues[rowIndex] != 0 {
+			locked = append(locked, fmt.Sprintf("r%d%s", row+1, rowValues[rowIndex]))
+		}
+	}
+	for colIndex, col := range cols {
+		for row := 0; row < 9; row++ {
+			if !containsLine(rows, row) {
+				for _, value := range (possible[row][col] & colValues[colIndex]).values() {
+					eliminations = append(eliminations, newCandidate(row, col, value))
+				}
+			}
+		}
+		if colValues[colIndex] != 0 {
+			locked = append(locked, fmt.Sprintf("c%d%s", col+1, colValues[colIndex]))
+		}
+	}
+	if len(eliminations) == 0 {
+		return nil
+	}
+	houses := []House{}
+	for _, row := range rows {
+		houses = append(houses, House{Kind: RowHouse, Index: row})
+	}
+	for _, col := range cols {
+		houses = append(houses, House{Kind: ColHouse, Index: col})
+	}
+	return &Step{
+		Technique:    TechniqueMSLS,
+		Eliminations: eliminations,
+		Cells:        cells,
+		Houses:       houses,
+		Reason: fmt.Sprintf("The %d unsolved cells where %s cross %s are locked by %d values %s",
+			len(cells), formatLines(RowHouse, rows), formatLines(ColHouse, cols), covered, strings.Join(locked, " ")),
+	}
+}
+
+// minimumCover returns the smallest set of rows and columns, as a bit per index,
+// covering every edge of the bipartite graph, where edges holds a bit for each
+// column joined to each row.  The cover is found from a maximum matching, by
+// König's theorem.
+func minimumCover(edges []uint16) (uint16, uint16) {
+
+	// Find a maximum matching by augmenting paths
+	matchOfCol := [maxMSLSLines]int{}
+	matchOfRow := [maxMSLSLines]int{}
+	for index := 0; index < maxMSLSLines; index++ {
+		matchOfCol[index], matchOfRow[index] = -1, -1
+	}
+	var augment func(row int, visited *uint16) bool
+	augment = func(row int, visited *uint16) bool {
+		for col := 0; col < maxMSLSLines; col++ {
+			if edges[row]&(1<<col) == 0 || *visited&(1<<col) != 0 {
+				continue
+			}
+			*visited |= 1 << col
+			if matchOfCol[col] < 0 || augment(matchOfCol[col], visited) {
+				matchOfCol[col], matchOfRow[row] = row, col
+				return true
+			}
+		}
+		return false
+	}
+	for row := range edges {
+		visited := uint16(0)
+		augment(row, &visited)
+	}
+
+	// Mark everything reachable by alternating paths from the unmatched rows
+	reachedRows, reachedCols := uint16(0), uint16(0)
+	queue := []int{}
+	for row := range edges {
+		if matchOfRow[row] < 0 {
+			reachedRows |= 1 << row
+			queue = append(queue, row)
+		}
+	}
+	for len(queue) > 0 {
+		row := queue[0]
+		queue = queue[1:]
+		for col := 0; col < maxMSLSLines; col++ {
+			if edges[row]&(1<<col) == 0 || reachedCols&(1<<col) != 0 {
+				continue
+			}
+			reachedCols |= 1 << col
+			if next := matchOfCol[col]; next >= 0 && reachedRows&(1<<next) == 0 {
+				reachedRows |= 1 << next
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	// The cover is the unreached rows and the reached columns
+	allRows := uint16(1)<<len(edges) - 1
+	return allRows &^ reachedRows, reachedCols
+}
+
+// containsLine returns whether the line index is in the list.
+func containsLine(lines []int, line int) bool {
+	for _, existing := range lines {
+		if existing == line {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testEasterMonster is a well known puzzle which resists the standard techniques,
+// along with its solution.
+const (
+	testEasterMonster         = "1.......2.9.4...5...6...7...5.9.3.......7.......85..4.7.....6...3...9.8...2.....1"
+	testEasterMonsterSolution = "174385962293467158586192734451923876928674315367851249719548623635219487842736591"
+)
+
+func TestExoticStrategies_Tier(t *testing.T) {
+	for _, strategy := range []Strategy{&skLoopStrategy{}, &juniorExocetStrategy{}, &mslsStrategy{}} {
+		assert.Equal(t, ExoticTier, strategyTier(strategy), strategy.Name())
+	}
+}
+
+func TestSKLoopStrategy(t *testing.T) {
+	strategy := &skLoopStrategy{}
+	assert.Equal(t, TechniqueSKLoop, strategy.Name())
+	assert.Equal(t, 6.0, strategy.Difficulty())
+
+	// Verify the loop around the 4 corner boxes of the Easter Monster
+	steps := testCollectSteps(testGridFromString(testEasterMonster), strategy)
+	assert.Len(t, steps, 1)
+	assert.Equal(t, TechniqueSKLoop, steps[0].Technique)
+	assert.Equal(t, "SK-Loop around pivots r2c2,r2c8,r8c8,r8c2 locks {3,8} into row 2, {1,6} into box 3, "+
+		"{3,9} into column 8, {2,7} into box 9, {4,5} into row 8, {1,6} into box 7, {4,8} into column 2, {2,7} into box 1",
+		steps[0].Reason)
+	assert.Len(t, steps[0].Eliminations, 13)
+	assertValidSteps(t, testEasterMonsterSolution, steps)
+}
+
+func TestJuniorExocetStrategy(t *testing.T) {
+	strategy := &juniorExocetStrategy{}
+	assert.Equal(t, TechniqueJuniorExocet, strategy.Name())
+	assert.Equal(t, 6.2, strategy.Difficulty())
+
+	// Unsolve a base in row 1 holding 2 and 9, with an extra value 7, and the
+	// targets r2c4 and r3c9, whose companions r3c4 and r2c9 hold no base value
+	grid := testGridFromString(testSolvedPuzzle)
+	unsolveCell(grid, 0, 0, 2, 7)
+	unsolveCell(grid, 0, 2, 7, 9)
+	unsolveCell(grid, 1, 3, 2, 5)
+	unsolveCell(grid, 2, 8, 9)
+
+	// Verify the target loses its other value and the base the value neither target holds
+	steps := strategy.Apply(grid)
+	assert.Len(t, steps, 1)
+	assert.Equal(t, "Base cells r1c1,r1c3 {2,7,9} with target cells r2c4,r3c9, whose companions r3c4,r2c9 "+
+		"hold no base value, must repeat the base values in the targets", steps[0].Reason)
+	assert.ElementsMatch(t, []Candidate{newCandidate(1, 3, 5), newCandidate(0, 0, 7), newCandidate(0, 2, 7)}, steps[0].Eliminations)
+	assert.Equal(t, []int{2}, grid.GetCell(1, 3).GetPossibleValues())
+	assert.Empty(t, strategy.Apply(grid))
+
+	// Verify the Junior Exocets found in a puzzle stalling on singles
+	steps = testCollectSteps(testGridFromString("...9..41...7..1.6....2...9...1.5...9.92.84...........3.143.2..........8.5.6...1.4"), strategy)
+	assert.NotEmpty(t, steps)
+	for _, step := range steps {
+		assert.Equal(t, TechniqueJuniorExocet, step.Technique)
+	}
+	assertValidSteps(t, "265938417987541362143276598471653829392784651658129743814362975739415286526897134", steps)
+}
+
+func TestCoveredByTwoHouses(t *testing.T) {
+	assert.True(t, coveredByTwoHouses([]Position{{0, 0}, {5, 5}}))
+	assert.True(t, coveredByTwoHouses([]Position{{0, 0}, {0, 4}, {6, 2}}))
+	assert.True(t, coveredByTwoHouses([]Position{{0, 3}, {4, 3}, {7, 8}, {7, 1}}))
+	assert.False(t, coveredByTwoHouses([]Position{{0, 0}, {4, 4}, {8, 8}}))
+}
+
+func TestMinimumCover(t *testing.T) {
+
+	// 3 rows joined only to the first column need only that column
+	rows, cols := minimumCover([]uint16{0b001, 0b001, 0b001})
+	assert.Equal(t, uint16(0), rows)
+	assert.Equal(t, uint16(0b001), cols)
+
+	// 2 rows joined to both columns need both of either
+	rows, cols = minimumCover([]uint16{0b11, 0b11})
+	assert.Equal(t, 2, bits.OnesCount16(rows)+bits.OnesCount16(cols))
+
+	// A row joined to every column, and a column joined to every row
+	rows, cols = minimumCover([]uint16{0b111, 0b001, 0b001})
+	assert.Equal(t, uint16(0b001), rows)
+	assert.Equal(t, uint16(0b001), cols)
+}
+
+func TestMSLSStrategy(t *testing.T) {
+	strategy := &mslsStrategy{}
+	assert.Equal(t, TechniqueMSLS, strategy.Name())
+	assert.Equal(t, 6.4, strategy.Difficulty())
+
+	// Verify the sets found in the Easter Monster
+	steps := testCollectSteps(testGridFromString(testEasterMonster), strategy)
+	assert.NotEmpty(t, steps)
+	assert.Equal(t, "The 16 unsolved cells where rows 2,4,6,8 cross columns 1,3,7,9 are locked by 16 values "+
+		"r2{3,8} r4{4,8} r6{3,9} r8{4,5} c1{2,6} c3{1,7} c7{1,2} c9{6,7}", steps[0].Reason)
+	assertValidSteps(t, testEasterMonsterSolution, steps)
+}
@@ -0,0 +1,240 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Names of the fish techniques, as reported in a SolveResult.
+const (
+	TechniqueXWing            = "X-Wing"            // 2 base lines covered by 2 cross lines
+	TechniqueSwordfish        = "Swordfish"         // 3 base lines covered by 3 cross lines
+	TechniqueJellyfish        = "Jellyfish"         // 4 base lines covered by 4 cross lines
+	TechniqueFinnedXWing      = "Finned X-Wing"     // X-Wing with extra candidates in a single Group
+	TechniqueFinnedSwordfish  = "Finned Swordfish"  // Swordfish with extra candidates in a single Group
+	TechniqueFinnedJellyfish  = "Finned Jellyfish"  // Jellyfish with extra candidates in a single Group
+	TechniqueSashimiXWing     = "Sashimi X-Wing"    // Finned X-Wing missing a candidate from a base line
+	TechniqueSashimiSwordfish = "Sashimi Swordfish" // Finned Swordfish missing a candidate from a base line
+	TechniqueSashimiJellyfish = "Sashimi Jellyfish" // Finned Jellyfish missing a candidate from a base line
+)
+
+// fishNames names the fish by size, e.g. "X-Wing" for 2.
+var fishNames = map[int]string{2: TechniqueXWing, 3: TechniqueSwordfish, 4: TechniqueJellyfish}
+
+// fishStrategy eliminates a single value using N base lines (Rows or Columns) in
+// which every possible Cell for the value lies within N cover lines running the
+// other way.  The value must then occupy the N cover lines within the base lines,
+// so it is eliminated from the rest of the cover lines.  When finned, the base
+// lines may also hold extra "fin" Cells provided they all lie in one Group, in
+// which case the value is only eliminated from cover Cells which also see the fins
+// in that Group.  A finned fish which would be incomplete without its fins is
+// reported as "Sashimi".
+type fishStrategy struct {
+	size   int  // Number of base and cover lines (2-4)
+	finned bool // Whether to look for finned and sashimi fish
+}
+
+func (f *fishStrategy) Name() string {
+	if f.finned {
+		return "Finned " + fishNames[f.size]
+	}
+	return fishNames[f.size]
+}
+
+func (f *fishStrategy) Difficulty() float64 {
+	difficulty := map[int]float64{2: 3.2, 3: 3.8, 4: 5.2}[f.size]
+	if f.finned {
+		difficulty = difficulty + 0.2
+	}
+	return difficulty
+}
+
+func (f *fishStrategy) Apply(grid *Grid) []Step {
+	for value := 1; value <= 9; value++ {
+		for _, baseKind := range []HouseKind{RowHouse, ColHouse} {
+			if step := f.findFish(grid, value, baseKind); step != nil {
+				applyStep(grid, *step)
+				return []Step{*step}
+			}
+		}
+	}
+	return nil
+}
+
+// findFish returns the Step for the first fish of the value found with base lines
+// of the specified kind, or nil if there are none which make progress.
+func (f *fishStrategy) findFish(grid *Grid, value int, baseKind HouseKind) *Step {
+	coverKind := ColHouse
+	if baseKind == ColHouse {
+		coverKind = RowHouse
+	}
+
+	// Collect the candidate lines, along with the cross lines (by index) where
+	// the value is possible in each
+	lines := []int{}
+	crossLines := map[int]candidateMask{}
+	for line := 0; line < 9; line++ {
+		mask := candidateMask(0)
+		for cross := 0; cross < 9; cross++ {
+			position := fishPosition(baseKind, line, cross)
+			if grid.GetCell(position.Row, position.Col).IsPossibleValue(value) {
+				mask |= 1 << cross
+			}
+		}
+		if mask.count() >= 2 {
+			lines = append(lines, line)
+			crossLines[line] = mask
+		}
+	}
+
+	// Look for N base lines which are covered by N cross lines (apart from fins)
+	var step *Step
+	combinations(len(lines), f.size, func(indexes []int) bool {
+		base := make([]int, f.size)
+		union := candidateMask(0)
+		for index, lineIndex := range indexes {
+			base[index] = lines[lineIndex]
+			union |= crossLines[base[index]]
+		}
+
+		// Basic fish need exactly N cover lines, finned fish need more
+		if !f.finned {
+			if union.count() != f.size {
+				return false
+			}
+			step = f.fishStep(grid, value, baseKind, coverKind, base, union, crossLines)
+			return step != nil
+		}
+		if union.count() <= f.size {
+			return false
+		}
+
+		// Try every choice of N cover lines leaving the rest as fins
+		unionLines := union.values()
+		return combinations(len(unionLines), f.size, func(coverIndexes []int) bool {
+			cover := candidateMask(0)
+			for _, coverIndex := range coverIndexes {
+				cover |= 1 << (unionLines[coverIndex] - 1)
+			}
+			step = f.fishStep(grid, value, baseKind, coverKind, base, cover, crossLines)
+			return step != nil
+		})
+	})
+	return step
+}
+
+// fishStep returns the Step for a fish of the value with the specified base lines
+// and cover lines (as a mask of cross line indexes), or nil if it is not a valid
+// fish or makes no progress.  Any possible Cells in the base lines outside the
+// cover lines are fins, which must all lie in a single Group.
+func (f *fishStrategy) fishStep(grid *Grid, value int, baseKind HouseKind, coverKind HouseKind,
+	base []int, cover candidateMask, crossLines map[int]candidateMask) *Step {
+
+	// Find the fins, and whether the fish would be incomplete without them
+	fins := []Position{}
+	sashimi := false
+	for _, line := range base {
+		if (crossLines[line] & cover).count() < 2 {
+			sashimi = true
+		}
+		if (crossLines[line] & cover) == 0 {
+			return nil // A base line without a cover Cell is not a fish
+		}
+		for _, cross := range (crossLines[line] &^ cover).values() {
+			fins = append(fins, fishPosition(baseKind, line, cross-1))
+		}
+	}
+	if sashimi && len(fins) == 0 {
+		return nil // Degenerate fish are left to simpler techniques
+	}
+	finGroup := -1
+	for _, fin := range fins {
+		group := (fin.Row/3)*3 + fin.Col/3
+		if finGroup >= 0 && group != finGroup {
+			return nil // Fins must all lie in the same Group
+		}
+		finGroup = group
+	}
+
+	// Eliminate the value from the cover lines outside the base lines, limited
+	// to the fin Group when there are fins
+	baseMask := candidateMask(0)
+	for _, line := range base {
+		baseMask |= 1 << line
+	}
+	eliminations := []Candidate{}
+	for _, cross := range cover.values() {
+		for line := 0; line < 9; line++ {
+			position := fishPosition(baseKind, line, cross-1)
+			if baseMask&(1<<line) != 0 || !grid.GetCell(position.Row, position.Col).IsPossibleValue(value) {
+				continue
+			}
+			if finGroup >= 0 && (position.Row/3)*3+position.Col/3 != finGroup {
+				continue
+			}
+			eliminations = append(eliminations, newCandidate(position.Row, position.Col, value))
+		}
+	}
+	if len(eliminations) == 0 {
+		return nil
+	}
+
+	// Name the fish and explain the base and cover sets
+	technique := fishNames[f.size]
+	reason := fmt.Sprintf("Value %d in base %s is covered by %s",
+		value, formatLines(baseKind, base), formatLines(coverKind, crossIndexes(cover)))
+	if len(fins) > 0 {
+		technique = "Finned " + technique
+		if sashimi {
+			technique = "Sashimi " + fishNames[f.size]
+		}
+		reason = fmt.Sprintf("%s with fins %s", reason, formatPositions(fins))
+	}
+	cells, houses := []Position{}, []House{}
+	for _, line := range base {
+		for _, cross := range crossLines[line].values() {
+			cells = append(cells, fishPosition(baseKind, line, cross-1))
+		}
+		houses = append(houses, House{Kind: baseKind, Index: line})
+	}
+	for _, cross := range crossIndexes(cover) {
+		houses = append(houses, House{Kind: coverKind, Index: cross})
+	}
+	return &Step{
+		Technique:    technique,
+		Eliminations: eliminations,
+		Cells:        cells,
+		Houses:       houses,
+		Reason:       reason,
+	}
+}
+
+// fishPosition returns the Position where the line of the specified kind crosses
+// the perpendicular line with the cross index.
+func fishPosition(kind HouseKind, line int, cross int) Position {
+	if kind == RowHouse {
+		return Position{Row: line, Col: cross}
+	}
+	return Position{Row: cross, Col: line}
+}
+
+// crossIndexes returns the zero-based line indexes in the mask.
+func crossIndexes(mask candidateMask) []int {
+	indexes := []int{}
+	for _, value := range mask.values() {
+		indexes = append(indexes, value-1)
+	}
+	return indexes
+}
+
+// formatLines returns the lines in the form "rows 1,4" or "columns 2,7".
+func formatLines(kind HouseKind, lines []int) string {
+	numbers := make([]string, len(lines))
+	for index, line := range lines {
+		numbers[index] = fmt.Sprint(line + 1)
+	}
+	if kind == RowHouse {
+		return "rows " + strings.Join(numbers, ",")
+	}
+	return "columns " + strings.Join(numbers, ",")
+}
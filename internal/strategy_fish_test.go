@@ -0,0 +1,102 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFishStrategy_Names(t *testing.T) {
+	assert.Equal(t, TechniqueXWing, (&fishStrategy{size: 2}).Name())
+	assert.Equal(t, TechniqueSwordfish, (&fishStrategy{size: 3}).Name())
+	assert.Equal(t, TechniqueJellyfish, (&fishStrategy{size: 4}).Name())
+	assert.Equal(t, TechniqueFinnedXWing, (&fishStrategy{size: 2, finned: true}).Name())
+	assert.Equal(t, TechniqueFinnedSwordfish, (&fishStrategy{size: 3, finned: true}).Name())
+	assert.Equal(t, TechniqueFinnedJellyfish, (&fishStrategy{size: 4, finned: true}).Name())
+	assert.Equal(t, 3.2, (&fishStrategy{size: 2}).Difficulty())
+	assert.Equal(t, 4.0, (&fishStrategy{size: 3, finned: true}).Difficulty())
+	assert.Equal(t, 5.2, (&fishStrategy{size: 4}).Difficulty())
+}
+
+func TestFishStrategy(t *testing.T) {
+
+	// Define The TestCases, restricting the value 1 in the first and fifth Rows
+	// of an empty Grid to the specified Columns
+	testCases := map[string]struct {
+		finned     bool
+		baseCols   map[int][]int // Columns where the value is possible by base Row
+		technique  string
+		eliminated []Position
+		reason     string
+	}{
+		"X-Wing": {
+			baseCols:   map[int][]int{0: {0, 4}, 4: {0, 4}},
+			technique:  TechniqueXWing,
+			eliminated: []Position{{1, 0}, {2, 0}, {3, 0}, {5, 0}, {6, 0}, {7, 0}, {8, 0}, {1, 4}, {2, 4}, {3, 4}, {5, 4}, {6, 4}, {7, 4}, {8, 4}},
+			reason:     "Value 1 in base rows 1,5 is covered by columns 1,5",
+		},
+		"Finned X-Wing": {
+			finned:     true,
+			baseCols:   map[int][]int{0: {0, 4}, 4: {0, 4, 5}},
+			technique:  TechniqueFinnedXWing,
+			eliminated: []Position{{3, 4}, {5, 4}},
+			reason:     "Value 1 in base rows 1,5 is covered by columns 1,5 with fins r5c6",
+		},
+		"Sashimi X-Wing": {
+			finned:     true,
+			baseCols:   map[int][]int{0: {0, 4}, 4: {0, 5}},
+			technique:  TechniqueSashimiXWing,
+			eliminated: []Position{{3, 4}, {5, 4}},
+			reason:     "Value 1 in base rows 1,5 is covered by columns 1,5 with fins r5c6",
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+
+			// Create the fish in an empty Grid
+			grid := NewGrid()
+			for row, cols := range testCase.baseCols {
+				keep := testCaseSubset(cols)
+				for col := 0; col < 9; col++ {
+					if !containsPosition(keep, Position{Row: 0, Col: col}) {
+						grid.GetCell(row, col).EliminateValue(1)
+					}
+				}
+			}
+
+			// Verify the fish is found and the value eliminated
+			strategy := &fishStrategy{size: 2, finned: testCase.finned}
+			steps := strategy.Apply(grid)
+			assert.Len(t, steps, 1)
+			assert.Equal(t, testCase.technique, steps[0].Technique)
+			assert.Equal(t, testCase.reason, steps[0].Reason)
+			assert.Len(t, steps[0].Eliminations, len(testCase.eliminated))
+			for _, position := range testCase.eliminated {
+				assert.False(t, grid.GetCell(position.Row, position.Col).IsPossibleValue(1))
+			}
+
+			// The opposite kind of fish is not found
+			assert.Empty(t, (&fishStrategy{size: 2, finned: !testCase.finned}).Apply(grid))
+		})
+	}
+}
+
+func TestFishStrategy_Puzzle(t *testing.T) {
+	puzzle := ".2..13.......7..965......1........7..946...5..8...76....3.8.......59.2..249......"
+	solution := "926813745431275896578964312612359478794628153385147629153482967867591234249736581"
+	for size := 2; size <= 4; size++ {
+		for _, finned := range []bool{false, true} {
+			strategy := &fishStrategy{size: size, finned: finned}
+			steps := testCollectSteps(testGridFromString(puzzle), strategy)
+			assert.NotEmpty(t, steps, strategy.Name())
+			assertValidSteps(t, solution, steps)
+		}
+	}
+}
+
+func TestFormatLines(t *testing.T) {
+	assert.Equal(t, "rows 1,4", formatLines(RowHouse, []int{0, 3}))
+	assert.Equal(t, "columns 2,7,9", formatLines(ColHouse, []int{1, 6, 8}))
+}
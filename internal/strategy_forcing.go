@@ -0,0 +1,323 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Names of the forcing techniques, as reported in a SolveResult.
+const (
+	TechniqueCellForcingChain = "Cell Forcing Chain" // Every possible value of a Cell forces the same conclusion
+	TechniqueUnitForcingChain = "Unit Forcing Chain" // Every possible Cell for a value in a House forces the same conclusion
+	TechniqueNishio           = "Nishio"             // Assuming a value leads to a contradiction
+)
+
+// maxForcingBranches limits the number of assumptions which must agree for a
+// forcing chain, i.e. the possible values of a Cell or Cells of a value in a House.
+const maxForcingBranches = 4
+
+// forcingTechniques are the techniques which make deductions by assuming a value
+// and following its implications, rather than by recognizing a pattern.
+var forcingTechniques = map[string]bool{
+	TechniqueCellForcingChain: true,
+	TechniqueUnitForcingChain: true,
+	TechniqueNishio:           true,
+}
+
+// conclusion is a value placed in, or eliminated from, a Cell.
+type conclusion struct {
+	Candidate
+	placed bool
+}
+
+// String returns the conclusion in the form "r4c7=5" or "r4c7<>5".
+func (c conclusion) String() string {
+	if c.placed {
+		return fmt.Sprintf("%s=%d", c.Position, c.Value)
+	}
+	return fmt.Sprintf("%s<>%d", c.Position, c.Value)
+}
+
+// implication is a conclusion reached while propagating an assumption, along with
+// the earlier implication which caused it.
+type implication struct {
+	conclusion
+	cause int // Index of the implication which caused this one, or -1 for the assumption
+}
+
+// propagation follows the implications of assuming a value on a scratch copy of
+// a Grid, placing naked and hidden singles until no more follow or a contradiction
+// is reached.  The live Grid is never updated.
+type propagation struct {
+	grid    *Grid              // The scratch copy holding the implications
+	trail   []implication      // The implications in the order they were reached
+	reached map[conclusion]int // The index of each conclusion in the trail
+	err     error              // The contradiction reached, if any
+	failure int                // Index of the implication which led to the contradiction
+}
+
+// propagate returns the propagation of placing the assumed value in a copy of the Grid.
+func propagate(grid *Grid, assumption Candidate) *propagation {
+	p := &propagation{grid: grid.Copy(), reached: map[conclusion]int{}}
+	pending := []implication{{conclusion: conclusion{Candidate: assumption, placed: true}, cause: -1}}
+	for len(pending) > 0 && p.err == nil {
+		next := pending[0]
+		pending = pending[1:]
+		cell := p.grid.GetCell(next.Row, next.Col)
+		if !cell.IsPossibleValue(next.Value) {
+			continue // Already placed, or eliminated by an earlier single
+		}
+		pending = append(pending, p.place(next)...)
+	}
+	return p
+}
+
+// place records the placement and the eliminations it causes, returning the
+// singles which follow from them.
+func (p *propagation) place(placement implication) []implication {
+	cause := p.record(placement)
+	position, value := placement.Position, placement.Value
+	eliminations := []Candidate{}
+	for _, other := range p.grid.GetCell(position.Row, position.Col).GetPossibleValues() {
+		if other != value {
+			eliminations = append(eliminations, newCandidate(position.Row, position.Col, other))
+		}
+	}
+	for _, peer := range position.peers() {
+		if p.grid.GetCell(peer.Row, peer.Col).IsPossibleValue(value) {
+			eliminations = append(eliminations, newCandidate(peer.Row, peer.Col, value))
+		}
+	}
+	p.grid.SetValue(position.Row, position.Col, value)
+
+	// Look for naked and hidden singles, or contradictions, caused by each elimination
+	singles := []implication{}
+	for _, elimination := range eliminations {
+		index := p.record(implication{conclusion: conclusion{Candidate: elimination}, cause: cause})
+		cell := p.grid.GetCell(elimination.Row, elimination.Col)
+		if cell.GetValue() == 0 {
+			switch remaining := cell.GetPossibleValues(); len(remaining) {
+			case 0:
+				p.fail(noCandidatesError(elimination.Position), index)
+				return nil
+			case 1:
+				singles = append(singles, implication{
+					conclusion: conclusion{Candidate: newCandidate(elimination.Row, elimination.Col, remaining[0]), placed: true},
+					cause:      index,
+				})
+			}
+		}
+		for _, house := range allHouses {
+			if !house.contains(elimination.Position) {
+				continue
+			}
+			possible, placed := []Position{}, false
+			for _, other := range house.Positions() {
+				otherCell := p.grid.GetCell(other.Row, other.Col)
+				placed = placed || otherCell.GetValue() == elimination.Value
+				if otherCell.IsPossibleValue(elimination.Value) {
+					possible = append(possible, other)
+				}
+			}
+			switch {
+			case placed:
+			case len(possible) == 0:
+				p.fail(missingValueError(house, elimination.Value), index)
+				return nil
+			case len(possible) == 1:
+				singles = append(singles, implication{
+					conclusion: conclusion{Candidate: newCandidate(possible[0].Row, possible[0].Col, elimination.Value), placed: true},
+					cause:      index,
+				})
+			}
+		}
+	}
+	return singles
+}
+
+// record appends the implication to the trail, returning its index.
+func (p *propagation) record(implication implication) int {
+	p.trail = append(p.trail, implication)
+	p.reached[implication.conclusion] = len(p.trail) - 1
+	return len(p.trail) - 1
+}
+
+// fail records the contradiction reached by the implication at the index.
+func (p *propagation) fail(err error, index int) {
+	p.err = err
+	p.failure = index
+}
+
+// path returns the chain of implications from the assumption to the implication
+// at the index, in the form "r1c1=5 -> r1c4<>5 -> r2c4=5".
+func (p *propagation) path(index int) string {
+	steps := []string{}
+	for ; index >= 0; index = p.trail[index].cause {
+		steps = append([]string{p.trail[index].String()}, steps...)
+	}
+	return strings.Join(steps, " -> ")
+}
+
+// forcingStrategy makes deductions by assuming values and propagating singles on
+// a scratch copy of the Grid.  A Cell Forcing Chain assumes each possible value of
+// a Cell in turn, and a Unit Forcing Chain each possible Cell for a value in a
+// House; since one of the assumptions must be true, any conclusion reached by all
+// of them is true.  Nishio assumes each possible value in turn, eliminating those
+// which lead to a contradiction.  Each Step lists the implication paths which verify it.
+type forcingStrategy struct {
+	technique string
+}
+
+func (f *forcingStrategy) Name() string { return f.technique }
+func (f *forcingStrategy) Difficulty() float64 {
+	return map[string]float64{
+		TechniqueCellForcingChain: 6.5,
+		TechniqueUnitForcingChain: 6.6,
+		TechniqueNishio:           7.0,
+	}[f.technique]
+}
+
+func (f *forcingStrategy) Apply(grid *Grid) []Step {
+
+	// Each contradiction found by Nishio is independent of the others, so every
+	// one found in a pass over the Grid is applied
+	if f.technique == TechniqueNishio {
+		steps := findNishio(grid)
+		for _, step := range steps {
+			applyStep(grid, step)
+		}
+		return steps
+	}
+
+	// Otherwise apply the first forcing chain found
+	var step *Step
+	if f.technique == TechniqueCellForcingChain {
+		step = findCellForcingChain(grid)
+	} else {
+		step = findUnitForcingChain(grid)
+	}
+	if step == nil {
+		return nil
+	}
+	step.Technique = f.technique
+	applyStep(grid, *step)
+	return []Step{*step}
+}
+
+// findCellForcingChain returns the Step for the first Cell whose possible values
+// all force the same conclusion, or nil.
+func findCellForcingChain(grid *Grid) *Step {
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			values := grid.GetCell(row, col).GetPossibleValues()
+			if len(values) < 2 || len(values) > maxForcingBranches {
+				continue
+			}
+			assumptions := make([]Candidate, len(values))
+			for index, value := range values {
+				assumptions[index] = newCandidate(row, col, value)
+			}
+			if step := forcingChainStep(grid, assumptions); step != nil {
+				step.Cells = []Position{{Row: row, Col: col}}
+				step.Reason = fmt.Sprintf("Every value of %s forces %s", Position{Row: row, Col: col}, step.Reason)
+				return step
+			}
+		}
+	}
+	return nil
+}
+
+// findUnitForcingChain returns the Step for the first value in a House whose
+// possible Cells all force the same conclusion, or nil.
+func findUnitForcingChain(grid *Grid) *Step {
+	for _, house := range allHouses {
+		for value := 1; value <= 9; value++ {
+			assumptions := []Candidate{}
+			for _, position := range house.Positions() {
+				if grid.GetCell(position.Row, position.Col).IsPossibleValue(value) {
+					assumptions = append(assumptions, newCandidate(position.Row, position.Col, value))
+				}
+			}
+			if len(assumptions) < 2 || len(assumptions) > maxForcingBranches {
+				continue
+			}
+			if step := forcingChainStep(grid, assumptions); step != nil {
+				for _, assumption := range assumptions {
+					step.Cells = append(step.Cells, assumption.Position)
+				}
+				step.Houses = []House{house}
+				step.Reason = fmt.Sprintf("Every cell for value %d in %s forces %s", value, house, step.Reason)
+				return step
+			}
+		}
+	}
+	return nil
+}
+
+// forcingChainStep propagates each of the assumptions, one of which must be true,
+// and returns the Step for the first conclusion they all reach, preferring
+// placements, or nil.  Assumptions leading to a contradiction are left to Nishio.
+// The Step's Reason is completed by the caller.
+func forcingChainStep(grid *Grid, assumptions []Candidate) *Step {
+	branches := make([]*propagation, len(assumptions))
+	for index, assumption := range assumptions {
+		branches[index] = propagate(grid, assumption)
+		if branches[index].err != nil {
+			return nil
+		}
+	}
+	for _, placed := range []bool{true, false} {
+		for _, implication := range branches[0].trail {
+			if implication.placed != placed || !reachedByAll(branches, implication.conclusion) {
+				continue
+			}
+			paths := make([]string, len(branches))
+			for index, branch := range branches {
+				paths[index] = branch.path(branch.reached[implication.conclusion])
+			}
+			step := &Step{Reason: fmt.Sprintf("%s: %s", implication.conclusion, strings.Join(paths, "; "))}
+			if placed {
+				step.Placements = []Candidate{implication.Candidate}
+			} else {
+				step.Eliminations = []Candidate{implication.Candidate}
+			}
+			return step
+		}
+	}
+	return nil
+}
+
+// reachedByAll returns whether every branch reached the conclusion.
+func reachedByAll(branches []*propagation, conclusion conclusion) bool {
+	for _, branch := range branches {
+		if _, ok := branch.reached[conclusion]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// findNishio returns a Step eliminating each possible value which leads to a
+// contradiction when assumed, or none.
+func findNishio(grid *Grid) []Step {
+	steps := []Step{}
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			for _, value := range grid.GetCell(row, col).GetPossibleValues() {
+				assumption := newCandidate(row, col, value)
+				branch := propagate(grid, assumption)
+				if branch.err == nil {
+					continue
+				}
+				steps = append(steps, Step{
+					Technique:    TechniqueNishio,
+					Eliminations: []Candidate{assumption},
+					Cells:        []Position{assumption.Position},
+					Reason: fmt.Sprintf("Assuming %s leads to a contradiction, %v: %s",
+						conclusion{Candidate: assumption, placed: true}, branch.err, branch.path(branch.failure)),
+				})
+			}
+		}
+	}
+	return steps
+}
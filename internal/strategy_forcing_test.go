@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConclusion_String(t *testing.T) {
+	assert.Equal(t, "r4c7=5", conclusion{Candidate: newCandidate(3, 6, 5), placed: true}.String())
+	assert.Equal(t, "r4c7<>5", conclusion{Candidate: newCandidate(3, 6, 5)}.String())
+}
+
+func TestPropagate(t *testing.T) {
+
+	// Unsolve 2 Cells of row 1, so placing either value forces the other
+	grid := testGridFromString(testSolvedPuzzle)
+	unsolveCell(grid, 0, 0, 2, 6)
+	unsolveCell(grid, 0, 1, 2, 6)
+
+	// Verify the implications are followed on a copy, leaving the Grid untouched
+	branch := propagate(grid, newCandidate(0, 0, 2))
+	assert.Nil(t, branch.err)
+	placed := conclusion{Candidate: newCandidate(0, 1, 6), placed: true}
+	assert.Contains(t, branch.reached, placed)
+	assert.Equal(t, "r1c1=2 -> r1c1<>6 -> r1c2=6", branch.path(branch.reached[placed]))
+	assert.True(t, branch.grid.IsSolved())
+	assert.Equal(t, 0, grid.GetCell(0, 0).GetValue())
+	assert.Equal(t, []int{2, 6}, grid.GetCell(0, 1).GetPossibleValues())
+}
+
+func TestPropagate_Contradiction(t *testing.T) {
+
+	// Unsolve 3 Cells of row 1 with only 2 values between them
+	grid := testGridFromString(testSolvedPuzzle)
+	unsolveCell(grid, 0, 0, 2, 6)
+	unsolveCell(grid, 0, 1, 2, 6)
+	unsolveCell(grid, 0, 2, 2, 6)
+
+	// Verify the contradiction and the path leading to it
+	branch := propagate(grid, newCandidate(0, 0, 2))
+	assert.EqualError(t, branch.err, "r1c3 has no candidates")
+	assert.Equal(t, "r1c1=2 -> r1c2<>2 -> r1c2=6 -> r1c3<>6", branch.path(branch.failure))
+	assert.Equal(t, []int{2, 6}, grid.GetCell(0, 2).GetPossibleValues())
+}
+
+func TestForcingStrategy(t *testing.T) {
+
+	// Define The TestCases
+	testCases := map[string]struct {
+		technique  string
+		difficulty float64
+		prefix     string
+		solves     bool
+	}{
+		"Cell Forcing Chain": {
+			technique:  TechniqueCellForcingChain,
+			difficulty: 6.5,
+			prefix:     "Every value of ",
+		},
+		"Unit Forcing Chain": {
+			technique:  TechniqueUnitForcingChain,
+			difficulty: 6.6,
+			prefix:     "Every cell for value ",
+		},
+		"Nishio": {
+			technique:  TechniqueNishio,
+			difficulty: 7.0,
+			prefix:     "Assuming ",
+			solves:     true,
+		},
+	}
+
+	// Execute The TestCases
+	puzzle := ".4.1.....9....8.4..67..45....3.65.....2....95..43......8..9.7.....5...13........4"
+	solution := "348156279925738146167924538793265481612847395854319627581493762476582913239671854"
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			strategy := &forcingStrategy{technique: testCase.technique}
+			assert.Equal(t, testCase.technique, strategy.Name())
+			assert.Equal(t, testCase.difficulty, strategy.Difficulty())
+
+			// Verify the Steps are valid and explain their implications
+			grid := testGridFromString(puzzle)
+			steps := testCollectSteps(grid, strategy)
+			assert.NotEmpty(t, steps)
+			assertValidSteps(t, solution, steps)
+			for _, step := range steps {
+				assert.Equal(t, testCase.technique, step.Technique)
+				assert.Contains(t, step.Reason, " -> ")
+				assert.Regexp(t, "^"+testCase.prefix, step.Reason)
+			}
+			assert.Equal(t, testCase.solves, grid.IsSolved())
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package internal
+
+import "fmt"
+
+// Names of the locked candidates techniques, as reported in a SolveResult.
+const (
+	TechniquePointing = "Pointing" // Value confined to one line within a Group
+	TechniqueClaiming = "Claiming" // Value confined to one Group within a line (box/line reduction)
+)
+
+// pointingStrategy eliminates a value from the rest of a Row or Column when,
+// within a Group, the value is only possible in the Cells shared with that line.
+type pointingStrategy struct{}
+
+func (p *pointingStrategy) Name() string        { return TechniquePointing }
+func (p *pointingStrategy) Difficulty() float64 { return 2.6 }
+func (p *pointingStrategy) Apply(grid *Grid) []Step {
+	return applyLockedCandidates(grid, TechniquePointing, true)
+}
+
+// claimingStrategy eliminates a value from the rest of a Group when, within a
+// Row or Column, the value is only possible in the Cells shared with that Group.
+type claimingStrategy struct{}
+
+func (c *claimingStrategy) Name() string        { return TechniqueClaiming }
+func (c *claimingStrategy) Difficulty() float64 { return 2.8 }
+func (c *claimingStrategy) Apply(grid *Grid) []Step {
+	return applyLockedCandidates(grid, TechniqueClaiming, false)
+}
+
+// applyLockedCandidates looks for a value whose possible Cells in one House of a
+// Group/line intersection are all within the intersection, and eliminates it from
+// the rest of the other House.  When pointing the Group is the confining House,
+// otherwise (claiming) the line is.  Applies and returns the first Step found.
+func applyLockedCandidates(grid *Grid, technique string, pointing bool) []Step {
+
+	// Loop over the intersections of every Group with every Row and Column
+	for _, intersection := range allIntersections {
+		confining, target := intersection.line, intersection.group
+		if pointing {
+			confining, target = intersection.group, intersection.line
+		}
+
+		// Loop over the possible Cell values (1-9)
+		for value := 1; value <= 9; value++ {
+
+			// The value must be possible within the intersection...
+			inside := []Position{}
+			for _, position := range intersection.positions {
+				if grid.GetCell(position.Row, position.Col).IsPossibleValue(value) {
+					inside = append(inside, position)
+				}
+			}
+			if len(inside) == 0 {
+				continue
+			}
+
+			// ...and nowhere else in the confining House
+			confined := true
+			for _, position := range confining.Positions() {
+				if !target.contains(position) && grid.GetCell(position.Row, position.Col).IsPossibleValue(value) {
+					confined = false
+					break
+				}
+			}
+			if !confined {
+				continue
+			}
+
+			// Eliminate the value from the rest of the target House
+			eliminations := eliminateFromHouse(grid, target, intersection.positions[:], maskOf(value))
+			if len(eliminations) == 0 {
+				continue
+			}
+			step := Step{
+				Technique:    technique,
+				Eliminations: eliminations,
+				Cells:        inside,
+				Houses:       []House{confining, target},
+				Reason: fmt.Sprintf("Value %d in %s is confined to cells %s shared with %s",
+					value, confining, formatPositions(inside), target),
+			}
+			applyStep(grid, step)
+			return []Step{step}
+		}
+	}
+
+	// No locked candidates made progress
+	return nil
+}
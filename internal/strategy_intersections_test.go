@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockedCandidatesStrategies(t *testing.T) {
+
+	// Define The TestCases, confining the value 1 to the first Row of the first Group
+	testCases := map[string]struct {
+		strategy   Strategy
+		name       string
+		difficulty float64
+		confine    []Position // Cells from which the value is removed to confine it
+		eliminated []Position // Cells from which the value is expected to be eliminated
+	}{
+		"Pointing": {
+			strategy:   &pointingStrategy{},
+			name:       TechniquePointing,
+			difficulty: 2.6,
+			confine:    []Position{{1, 0}, {1, 1}, {1, 2}, {2, 0}, {2, 1}, {2, 2}},
+			eliminated: []Position{{0, 3}, {0, 4}, {0, 5}, {0, 6}, {0, 7}, {0, 8}},
+		},
+		"Claiming": {
+			strategy:   &claimingStrategy{},
+			name:       TechniqueClaiming,
+			difficulty: 2.8,
+			confine:    []Position{{0, 3}, {0, 4}, {0, 5}, {0, 6}, {0, 7}, {0, 8}},
+			eliminated: []Position{{1, 0}, {1, 1}, {1, 2}, {2, 0}, {2, 1}, {2, 2}},
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			assert.Equal(t, testCase.name, testCase.strategy.Name())
+			assert.Equal(t, testCase.difficulty, testCase.strategy.Difficulty())
+
+			// Confine the value in an empty Grid
+			grid := NewGrid()
+			for _, position := range testCase.confine {
+				grid.GetCell(position.Row, position.Col).EliminateValue(1)
+			}
+
+			// Verify the value is eliminated from the rest of the other House
+			steps := testCase.strategy.Apply(grid)
+			assert.Len(t, steps, 1)
+			assert.Equal(t, testCase.name, steps[0].Technique)
+			assert.Len(t, steps[0].Eliminations, len(testCase.eliminated))
+			for _, position := range testCase.eliminated {
+				assert.False(t, grid.GetCell(position.Row, position.Col).IsPossibleValue(1))
+			}
+			assert.Empty(t, testCase.strategy.Apply(grid))
+		})
+	}
+}
+
+func TestLockedCandidatesStrategies_Puzzle(t *testing.T) {
+
+	// Define The TestCases
+	testCases := map[string]struct {
+		strategy Strategy
+		puzzle   string
+		solution string
+	}{
+		"Pointing": {
+			strategy: &pointingStrategy{},
+			puzzle:   "...6.....39.....41......3.......1...9....4.7.68....2...6..8..5..4.7....9.2.4.976.",
+			solution: "812643597395278641476915382257861934931524876684397215769182453548736129123459768",
+		},
+		"Claiming": {
+			strategy: &claimingStrategy{},
+			puzzle:   "....76....124....5....1..8..7..32....29..86....86....34....1.56......31....36...2",
+			solution: "985276134712483965634915287576132498329548671148697523493721856267854319851369742",
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			steps := testCollectSteps(testGridFromString(testCase.puzzle), testCase.strategy)
+			assert.NotEmpty(t, steps)
+			assertValidSteps(t, testCase.solution, steps)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TechniquePatternOverlay is the name of the Pattern Overlay Method, as reported in a SolveResult.
+const TechniquePatternOverlay = "Pattern Overlay"
+
+// digitTemplates holds every template for a single value, i.e. every way to place
+// it once in each Row, Column and Group of an empty Grid, as a set of 9 Cells.
+// There are 9*6*3 * 6*4*2 * 3*2*1 = 46,656 of them.
+var digitTemplates = func() []cellSet {
+	templates := make([]cellSet, 0, 46656)
+	var place func(row int, usedCols uint16, usedGroups uint16, template cellSet)
+	place = func(row int, usedCols uint16, usedGroups uint16, template cellSet) {
+		if row == 9 {
+			templates = append(templates, template)
+			return
+		}
+		for col := 0; col < 9; col++ {
+			group := (row/3)*3 + col/3
+			if usedCols&(1<<col) != 0 || usedGroups&(1<<group) != 0 {
+				continue
+			}
+			place(row+1, usedCols|1<<col, usedGroups|1<<group, template.or(cellSetOf(Position{Row: row, Col: col})))
+		}
+	}
+	place(0, 0, 0, cellSet{})
+	return templates
+}()
+
+// fittingTemplates returns the templates for the value which fit the Grid, i.e.
+// which cover every Cell already holding the value and otherwise only Cells where
+// it is still possible.
+func fittingTemplates(grid *Grid, value int) []cellSet {
+	placed, allowed := cellSet{}, cellSet{}
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			cell := grid.GetCell(row, col)
+			if cell.GetValue() == value {
+				placed = placed.or(cellSetOf(Position{Row: row, Col: col}))
+			}
+			if cell.GetValue() == value || cell.IsPossibleValue(value) {
+				allowed = allowed.or(cellSetOf(Position{Row: row, Col: col}))
+			}
+		}
+	}
+	fitting := []cellSet{}
+	for _, template := range digitTemplates {
+		if placed.andNot(template).isEmpty() && template.andNot(allowed).isEmpty() {
+			fitting = append(fitting, template)
+		}
+	}
+	return fitting
+}
+
+// patternOverlayStrategy (the Pattern Overlay Method) overlays every template of a
+// value which fits the Grid.  Since the solution places the value according to one
+// of them, a possible value covered by no fitting template is eliminated, and a
+// Cell covered by every fitting template must hold the value.  This subsumes every
+// single value pattern, such as fish and coloring, without any guessing.
+type patternOverlayStrategy struct{}
+
+func (p *patternOverlayStrategy) Name() string        { return TechniquePatternOverlay }
+func (p *patternOverlayStrategy) Difficulty() float64 { return 6.3 }
+func (p *patternOverlayStrategy) Apply(grid *Grid) []Step {
+	for value := 1; value <= 9; value++ {
+		if step := patternOverlayStep(grid, value); step != nil {
+			applyStep(grid, *step)
+			return []Step{*step}
+		}
+	}
+	return nil
+}
+
+// patternOverlayStep returns the Step placing the value in the Cells covered by
+// every fitting template, and eliminating it from those covered by none, or nil
+// if that makes no progress.  Should no template fit, the Grid is inconsistent and
+// the contradiction is left for the Solver to detect.
+func patternOverlayStep(grid *Grid, value int) *Step {
+	templates := fittingTemplates(grid, value)
+	if len(templates) == 0 {
+		return nil
+	}
+	union, intersection := cellSet{}, cellSet{^uint64(0), ^uint64(0)}
+	for _, template := range templates {
+		union = union.or(template)
+		intersection = intersection.and(template)
+	}
+
+	// Only unsolved Cells where the value is possible can make progress
+	possible := cellSet{}
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			if grid.GetCell(row, col).IsPossibleValue(value) {
+				possible = possible.or(cellSetOf(Position{Row: row, Col: col}))
+			}
+		}
+	}
+	placements := intersection.and(possible).positions()
+	eliminations := possible.andNot(union).positions()
+	if len(placements) == 0 && len(eliminations) == 0 {
+		return nil
+	}
+	clauses := []string{}
+	if len(placements) > 0 {
+		clauses = append(clauses, fmt.Sprintf("all place it in %s", formatPositions(placements)))
+	}
+	if len(eliminations) > 0 {
+		clauses = append(clauses, fmt.Sprintf("none cover %s", formatPositions(eliminations)))
+	}
+	return &Step{
+		Technique:    TechniquePatternOverlay,
+		Placements:   candidatesOf(placements, value),
+		Eliminations: candidatesOf(eliminations, value),
+		Cells:        append(append([]Position{}, placements...), eliminations...),
+		Reason: fmt.Sprintf("Of the %d templates for value %d which fit the grid, %s",
+			len(templates), value, strings.Join(clauses, " and ")),
+	}
+}
@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigitTemplates(t *testing.T) {
+	assert.Len(t, digitTemplates, 46656)
+
+	// Verify every template places the value once in each House
+	for _, template := range digitTemplates[:100] {
+		for _, house := range allHouses {
+			positions := house.Positions()
+			assert.Len(t, template.and(cellSetOf(positions[:]...)).positions(), 1, house.String())
+		}
+	}
+	assert.Equal(t, []Position{{0, 0}, {1, 3}, {2, 6}, {3, 1}, {4, 4}, {5, 7}, {6, 2}, {7, 5}, {8, 8}}, digitTemplates[0].positions())
+}
+
+func TestFittingTemplates(t *testing.T) {
+
+	// Verify a solved Grid fits exactly one template per value
+	grid := testGridFromString(testSolvedPuzzle)
+	for value := 1; value <= 9; value++ {
+		assert.Len(t, fittingTemplates(grid, value), 1)
+	}
+
+	// Verify an empty Grid fits every template
+	assert.Len(t, fittingTemplates(NewGrid(), 5), 46656)
+}
+
+func TestPatternOverlayStrategy(t *testing.T) {
+	strategy := &patternOverlayStrategy{}
+	assert.Equal(t, TechniquePatternOverlay, strategy.Name())
+	assert.Equal(t, 6.3, strategy.Difficulty())
+
+	// Unsolve 2 Cells of row 1 which could both hold value 2
+	grid := testGridFromString(testSolvedPuzzle)
+	unsolveCell(grid, 0, 0, 2, 6)
+	unsolveCell(grid, 0, 1, 2, 6)
+
+	// Verify the only template with the other 2s places it in r1c1, eliminating it from r1c2
+	steps := strategy.Apply(grid)
+	assert.Len(t, steps, 1)
+	assert.Equal(t, "Of the 1 templates for value 2 which fit the grid, all place it in r1c1 and none cover r1c2", steps[0].Reason)
+	assert.Equal(t, []Candidate{newCandidate(0, 0, 2)}, steps[0].Placements)
+	assert.Equal(t, []Candidate{newCandidate(0, 1, 2)}, steps[0].Eliminations)
+	assert.Equal(t, 2, grid.GetCell(0, 0).GetValue())
+
+	// Verify value 6 is then placed in r1c2, solving the Grid
+	steps = strategy.Apply(grid)
+	assert.Len(t, steps, 1)
+	assert.Equal(t, []Candidate{newCandidate(0, 1, 6)}, steps[0].Placements)
+	assert.True(t, grid.IsSolved())
+	assert.Empty(t, strategy.Apply(grid))
+}
+
+func TestPatternOverlayStrategy_Puzzle(t *testing.T) {
+	puzzle := ".4.1.....9....8.4..67..45....3.65.....2....95..43......8..9.7.....5...13........4"
+	solution := "348156279925738146167924538793265481612847395854319627581493762476582913239671854"
+	steps := testCollectSteps(testGridFromString(puzzle), &patternOverlayStrategy{})
+	assert.NotEmpty(t, steps)
+	assert.Equal(t, "Of the 19 templates for value 1 which fit the grid, none cover r6c1", steps[0].Reason)
+	assertValidSteps(t, solution, steps)
+}
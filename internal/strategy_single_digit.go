@@ -0,0 +1,223 @@
+package internal
+
+import "fmt"
+
+// Names of the single digit pattern techniques, as reported in a SolveResult.
+const (
+	TechniqueSkyscraper     = "Skyscraper"      // Strong links in 2 parallel lines joined across a line
+	TechniqueTwoStringKite  = "2-String Kite"   // Strong links in a Row and a Column joined in a Group
+	TechniqueTurbotFish     = "Turbot Fish"     // Any other pair of strong links joined by a weak link
+	TechniqueEmptyRectangle = "Empty Rectangle" // Value in a Group confined to a Row and Column, with a strong link
+)
+
+// strongLink is a House in which a value is possible in exactly 2 Cells, so one
+// of them must hold the value (bilocation).
+type strongLink struct {
+	house House
+	ends  [2]Position
+}
+
+// strongLinks returns every strong link for the value across all Houses.
+func strongLinks(grid *Grid, value int) []strongLink {
+	links := []strongLink{}
+	for _, house := range allHouses {
+		ends := []Position{}
+		for _, position := range house.Positions() {
+			if grid.GetCell(position.Row, position.Col).IsPossibleValue(value) {
+				ends = append(ends, position)
+			}
+		}
+		if len(ends) == 2 {
+			links = append(links, strongLink{house: house, ends: [2]Position{ends[0], ends[1]}})
+		}
+	}
+	return links
+}
+
+// twoLinkStrategy eliminates a value using 2 strong links joined by a weak link,
+// i.e. the chain x=y-u=v where y and u see each other.  Either x or v must hold
+// the value, so it is eliminated from every Cell which sees both.  The technique
+// name depends on the Houses of the strong links and how they are joined.
+type twoLinkStrategy struct {
+	technique string
+}
+
+func (t *twoLinkStrategy) Name() string { return t.technique }
+func (t *twoLinkStrategy) Difficulty() float64 {
+	switch t.technique {
+	case TechniqueSkyscraper, TechniqueTwoStringKite:
+		return 3.3
+	default:
+		return 3.4
+	}
+}
+
+func (t *twoLinkStrategy) Apply(grid *Grid) []Step {
+	for value := 1; value <= 9; value++ {
+		links := strongLinks(grid, value)
+		for first := range links {
+			for second := range links {
+				if first == second {
+					continue
+				}
+
+				// Try each orientation of the links as x=y and u=v
+				for _, firstEnd := range []int{0, 1} {
+					for _, secondEnd := range []int{0, 1} {
+						x, y := links[first].ends[firstEnd], links[first].ends[1-firstEnd]
+						u, v := links[second].ends[secondEnd], links[second].ends[1-secondEnd]
+						if !y.sees(u) || containsPosition([]Position{y, u}, x) || containsPosition([]Position{x, y, u}, v) {
+							continue
+						}
+						if classifyTwoLinks(links[first].house, links[second].house, y, u) != t.technique {
+							continue
+						}
+						eliminations := eliminateSeenByBoth(grid, value, x, v)
+						if len(eliminations) == 0 {
+							continue
+						}
+						step := Step{
+							Technique:    t.technique,
+							Eliminations: eliminations,
+							Cells:        []Position{x, y, u, v},
+							Houses:       []House{links[first].house, links[second].house},
+							Reason: fmt.Sprintf("Strong links in %s and %s form %s=%s-%s=%s",
+								links[first].house, links[second].house,
+								newCandidate(x.Row, x.Col, value), newCandidate(y.Row, y.Col, value),
+								newCandidate(u.Row, u.Col, value), newCandidate(v.Row, v.Col, value)),
+						}
+						applyStep(grid, step)
+						return []Step{step}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// classifyTwoLinks returns the technique name for strong links in the two Houses
+// joined by a weak link between the Cells y and u.
+func classifyTwoLinks(first House, second House, y Position, u Position) string {
+	switch {
+	case first.Kind == RowHouse && second.Kind == RowHouse && y.Col == u.Col:
+		return TechniqueSkyscraper
+	case first.Kind == ColHouse && second.Kind == ColHouse && y.Row == u.Row:
+		return TechniqueSkyscraper
+	case first.Kind != GroupHouse && second.Kind != GroupHouse && first.Kind != second.Kind &&
+		y.Row/3 == u.Row/3 && y.Col/3 == u.Col/3:
+		return TechniqueTwoStringKite
+	default:
+		return TechniqueTurbotFish
+	}
+}
+
+// eliminateSeenByBoth returns the eliminations of the value from every Cell which
+// sees both of the Positions, where it is still possible.
+func eliminateSeenByBoth(grid *Grid, value int, first Position, second Position) []Candidate {
+	eliminations := []Candidate{}
+	for _, position := range first.peers() {
+		if position.sees(second) && grid.GetCell(position.Row, position.Col).IsPossibleValue(value) {
+			eliminations = append(eliminations, newCandidate(position.Row, position.Col, value))
+		}
+	}
+	return eliminations
+}
+
+// emptyRectangleStrategy eliminates a value using a Group where the value is
+// confined to one Row and one Column of the Group (leaving an "empty rectangle"),
+// together with a strong link in a line outside the Group with one end in the
+// Group's Row (or Column).  The value is eliminated where the Group's Column (or
+// Row) crosses the line through the strong link's other end.
+type emptyRectangleStrategy struct{}
+
+func (e *emptyRectangleStrategy) Name() string        { return TechniqueEmptyRectangle }
+func (e *emptyRectangleStrategy) Difficulty() float64 { return 3.5 }
+func (e *emptyRectangleStrategy) Apply(grid *Grid) []Step {
+	for value := 1; value <= 9; value++ {
+		links := strongLinks(grid, value)
+		for group := 0; group < 9; group++ {
+			groupHouse := House{Kind: GroupHouse, Index: group}
+
+			// Find the value's possible Cells in the Group
+			cells := []Position{}
+			for _, position := range groupHouse.Positions() {
+				if grid.GetCell(position.Row, position.Col).IsPossibleValue(value) {
+					cells = append(cells, position)
+				}
+			}
+			if len(cells) < 2 {
+				continue
+			}
+
+			// Try each Row and Column of the Group which could confine the Cells
+			for _, erRow := range []int{(group / 3) * 3, (group/3)*3 + 1, (group/3)*3 + 2} {
+				for _, erCol := range []int{(group % 3) * 3, (group%3)*3 + 1, (group%3)*3 + 2} {
+					if !isEmptyRectangle(cells, erRow, erCol) {
+						continue
+					}
+					if step := emptyRectangleStep(grid, value, groupHouse, cells, erRow, erCol, links); step != nil {
+						applyStep(grid, *step)
+						return []Step{*step}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isEmptyRectangle returns whether every Cell lies in the Row or Column, with at
+// least one Cell in each of them away from their intersection.
+func isEmptyRectangle(cells []Position, row int, col int) bool {
+	inRow, inCol := false, false
+	for _, position := range cells {
+		switch {
+		case position.Row == row && position.Col == col:
+		case position.Row == row:
+			inRow = true
+		case position.Col == col:
+			inCol = true
+		default:
+			return false
+		}
+	}
+	return inRow && inCol
+}
+
+// emptyRectangleStep returns the Step for the empty rectangle of the value in the
+// Group's Cells confined to erRow/erCol, using the first strong link which makes progress,
+// or nil if none do.
+func emptyRectangleStep(grid *Grid, value int, group House, cells []Position, erRow int, erCol int, links []strongLink) *Step {
+	for _, link := range links {
+		for end := 0; end < 2; end++ {
+			near, far := link.ends[end], link.ends[1-end]
+
+			// A Column link with an end in the Group's Row eliminates where the
+			// Group's Column crosses the Row of the far end, and vice versa
+			var target Position
+			switch {
+			case link.house.Kind == ColHouse && near.Row == erRow && near.Col/3 != erCol/3 && far.Row/3 != erRow/3:
+				target = Position{Row: far.Row, Col: erCol}
+			case link.house.Kind == RowHouse && near.Col == erCol && near.Row/3 != erRow/3 && far.Col/3 != erCol/3:
+				target = Position{Row: erRow, Col: far.Col}
+			default:
+				continue
+			}
+			if !grid.GetCell(target.Row, target.Col).IsPossibleValue(value) {
+				continue
+			}
+			step := &Step{
+				Technique:    TechniqueEmptyRectangle,
+				Eliminations: []Candidate{newCandidate(target.Row, target.Col, value)},
+				Cells:        append(append([]Position{}, cells...), near, far),
+				Houses:       []House{group, link.house},
+				Reason: fmt.Sprintf("Value %d in %s is confined to row %d and column %d, with strong link %s=%s in %s",
+					value, group, erRow+1, erCol+1,
+					newCandidate(near.Row, near.Col, value), newCandidate(far.Row, far.Col, value), link.house),
+			}
+			return step
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrongLinks(t *testing.T) {
+
+	// Confine value 1 to 2 Cells of column 1, which also leaves box 1 unchanged
+	grid := NewGrid()
+	for row := 1; row < 9; row++ {
+		if row != 4 {
+			grid.GetCell(row, 0).EliminateValue(1)
+		}
+	}
+
+	// Verify the only strong link is in column 1
+	links := strongLinks(grid, 1)
+	assert.Equal(t, []strongLink{{house: House{Kind: ColHouse, Index: 0}, ends: [2]Position{{0, 0}, {4, 0}}}}, links)
+	assert.Empty(t, strongLinks(grid, 2))
+}
+
+func TestTwoLinkStrategy(t *testing.T) {
+
+	// Define The TestCases, confining value 1 to the listed Cells of each House
+	testCases := map[string]struct {
+		technique  string
+		difficulty float64
+		links      map[House][]Position
+		eliminated []Candidate
+		reason     string
+	}{
+		"Skyscraper": {
+			technique:  TechniqueSkyscraper,
+			difficulty: 3.3,
+			links:      map[House][]Position{{ColHouse, 0}: {{0, 0}, {4, 0}}, {ColHouse, 5}: {{1, 5}, {4, 5}}},
+			eliminated: []Candidate{newCandidate(0, 3, 1), newCandidate(0, 4, 1), newCandidate(1, 1, 1), newCandidate(1, 2, 1)},
+			reason:     "Strong links in column 1 and column 6 form (1)r1c1=(1)r5c1-(1)r5c6=(1)r2c6",
+		},
+		"2-String Kite": {
+			technique:  TechniqueTwoStringKite,
+			difficulty: 3.3,
+			links:      map[House][]Position{{RowHouse, 0}: {{0, 1}, {0, 6}}, {ColHouse, 0}: {{2, 0}, {6, 0}}},
+			eliminated: []Candidate{newCandidate(6, 6, 1)},
+			reason:     "Strong links in row 1 and column 1 form (1)r1c7=(1)r1c2-(1)r3c1=(1)r7c1",
+		},
+		"Turbot Fish": {
+			technique:  TechniqueTurbotFish,
+			difficulty: 3.4,
+			links:      map[House][]Position{{ColHouse, 5}: {{1, 5}, {6, 5}}, {GroupHouse, 0}: {{1, 1}, {2, 2}}},
+			eliminated: []Candidate{newCandidate(6, 2, 1)},
+			reason:     "Strong links in column 6 and box 1 form (1)r7c6=(1)r2c6-(1)r2c2=(1)r3c3",
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			strategy := &twoLinkStrategy{technique: testCase.technique}
+			assert.Equal(t, testCase.technique, strategy.Name())
+			assert.Equal(t, testCase.difficulty, strategy.Difficulty())
+
+			// Create the strong links in an empty Grid
+			grid := NewGrid()
+			confineValue(grid, 1, testCase.links)
+
+			// Verify the pattern is found and the value eliminated
+			steps := strategy.Apply(grid)
+			assert.Len(t, steps, 1)
+			assert.Equal(t, testCase.technique, steps[0].Technique)
+			assert.Equal(t, testCase.reason, steps[0].Reason)
+			assert.ElementsMatch(t, testCase.eliminated, steps[0].Eliminations)
+			assert.Empty(t, strategy.Apply(grid))
+		})
+	}
+}
+
+func TestEmptyRectangleStrategy(t *testing.T) {
+	strategy := &emptyRectangleStrategy{}
+	assert.Equal(t, TechniqueEmptyRectangle, strategy.Name())
+	assert.Equal(t, 3.5, strategy.Difficulty())
+
+	// Confine value 1 in box 1 to row 1 and column 1, and in column 5 to rows 1 and 7
+	grid := NewGrid()
+	for _, position := range []Position{{1, 1}, {1, 2}, {2, 1}, {2, 2}} {
+		grid.GetCell(position.Row, position.Col).EliminateValue(1)
+	}
+	for row := 0; row < 9; row++ {
+		if row != 0 && row != 6 {
+			grid.GetCell(row, 4).EliminateValue(1)
+		}
+	}
+
+	// Verify the value is eliminated where column 1 crosses row 7
+	steps := strategy.Apply(grid)
+	assert.Len(t, steps, 1)
+	assert.Equal(t, TechniqueEmptyRectangle, steps[0].Technique)
+	assert.Equal(t, "Value 1 in box 1 is confined to row 1 and column 1, with strong link (1)r1c5=(1)r7c5 in column 5", steps[0].Reason)
+	assert.Equal(t, []Candidate{newCandidate(6, 0, 1)}, steps[0].Eliminations)
+	assert.Empty(t, strategy.Apply(grid))
+}
+
+func TestIsEmptyRectangle(t *testing.T) {
+	assert.True(t, isEmptyRectangle([]Position{{0, 0}, {0, 2}, {2, 0}}, 0, 0))
+	assert.True(t, isEmptyRectangle([]Position{{0, 2}, {2, 0}}, 0, 0))
+	assert.False(t, isEmptyRectangle([]Position{{0, 0}, {0, 2}}, 0, 0))
+	assert.False(t, isEmptyRectangle([]Position{{0, 2}, {2, 0}, {1, 1}}, 0, 0))
+}
+
+func TestSingleDigitStrategies_Puzzle(t *testing.T) {
+
+	// Define The TestCases
+	testCases := map[string]struct {
+		strategy Strategy
+		puzzle   string
+		solution string
+	}{
+		"Skyscraper": {
+			strategy: &twoLinkStrategy{technique: TechniqueSkyscraper},
+			puzzle:   ".2..13.......7..965......1........7..946...5..8...76....3.8.......59.2..249......",
+			solution: "926813745431275896578964312612359478794628153385147629153482967867591234249736581",
+		},
+		"2-String Kite": {
+			strategy: &twoLinkStrategy{technique: TechniqueTwoStringKite},
+			puzzle:   "....76....124....5....1..8..7..32....29..86....86....34....1.56......31....36...2",
+			solution: "985276134712483965634915287576132498329548671148697523493721856267854319851369742",
+		},
+		"Turbot Fish": {
+			strategy: &twoLinkStrategy{technique: TechniqueTurbotFish},
+			puzzle:   "....76....124....5....1..8..7..32....29..86....86....34....1.56......31....36...2",
+			solution: "985276134712483965634915287576132498329548671148697523493721856267854319851369742",
+		},
+		"Empty Rectangle": {
+			strategy: &emptyRectangleStrategy{},
+			puzzle:   "....76....124....5....1..8..7..32....29..86....86....34....1.56......31....36...2",
+			solution: "985276134712483965634915287576132498329548671148697523493721856267854319851369742",
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			steps := testCollectSteps(testGridFromString(testCase.puzzle), testCase.strategy)
+			assert.NotEmpty(t, steps)
+			assertValidSteps(t, testCase.solution, steps)
+		})
+	}
+}
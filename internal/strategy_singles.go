@@ -0,0 +1,235 @@
+package internal
+
+import "fmt"
+
+// nakedSingleStrategy sets any Cell where only 1 value is still possible.
+type nakedSingleStrategy struct{}
+
+func (n *nakedSingleStrategy) Name() string        { return TechniqueNakedSingle }
+func (n *nakedSingleStrategy) Difficulty() float64 { return 2.3 }
+func (n *nakedSingleStrategy) Apply(grid *Grid) []Step {
+	return setSinglePossibleValueInGrid(grid)
+}
+
+// hiddenSingleStrategy sets any Cell where a possible value MUST belong for
+// a Row, Column, or Group because it has been eliminated from all other Cells
+// in that House.
+type hiddenSingleStrategy struct {
+	kind HouseKind
+}
+
+func (h *hiddenSingleStrategy) Name() string {
+	switch h.kind {
+	case RowHouse:
+		return TechniqueHiddenSingleRow
+	case ColHouse:
+		return TechniqueHiddenSingleCol
+	default:
+		return TechniqueHiddenSingleGroup
+	}
+}
+
+func (h *hiddenSingleStrategy) Difficulty() float64 {
+	if h.kind == GroupHouse {
+		return 1.2
+	}
+	return 1.5
+}
+
+func (h *hiddenSingleStrategy) Apply(grid *Grid) []Step {
+	switch h.kind {
+	case RowHouse:
+		return setOnlyPossibleValueInRow(grid)
+	case ColHouse:
+		return setOnlyPossibleValueInCol(grid)
+	default:
+		return setOnlyPossibleValueInGroup(grid)
+	}
+}
+
+// setSinglePossibleValueInGrid updates the Grid by Setting the value of
+// any Cell which has only a single possible value remaining.
+func setSinglePossibleValueInGrid(grid *Grid) []Step {
+
+	// Track the Steps applied to the Grid
+	steps := []Step{}
+
+	// Loop over all the Cells in the Grid
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+
+			// If the Cell only has a single remaining possible value, then set it!
+			possibleValues := grid.GetCell(row, col).GetPossibleValues()
+			if len(possibleValues) == 1 {
+				value := possibleValues[0]
+				steps = append(steps, placeValue(grid, TechniqueNakedSingle, row, col, value, "Only one possible value remaining for cell"))
+			}
+		}
+	}
+
+	// Return the Steps applied
+	return steps
+}
+
+// setOnlyPossibleValueInRow updates the Grid by Setting the value of any Cell
+// which is the only remaining Cell in its Row with a particular possible Value.
+func setOnlyPossibleValueInRow(grid *Grid) []Step {
+
+	// Track the Steps applied to the Grid
+	steps := []Step{}
+
+	// Loop over the 9 Rows
+	for row := 0; row < 9; row++ {
+
+		// Loop over the possible Cell values (1-9)
+		for value := 1; value <= 9; value++ {
+			valueCount := 0
+			valueCol := -1
+
+			// Loop over the 9 Columns for the current Row
+			for col := 0; col < 9; col++ {
+
+				// Check the Cell's possible values for the current value
+				if grid.GetCell(row, col).IsPossibleValue(value) {
+					valueCount = valueCount + 1
+					valueCol = col
+				}
+
+				// If the value already exists then cease further checks
+				if valueCount > 1 {
+					valueCol = -1
+					break
+				}
+			}
+
+			// If only a single Cell in the Row has the possible value, then set it!
+			if valueCount == 1 {
+				steps = append(steps, placeHiddenSingle(grid, TechniqueHiddenSingleRow, row, valueCol, value, House{Kind: RowHouse, Index: row}))
+			}
+		}
+	}
+
+	// Return the Steps applied
+	return steps
+}
+
+// setOnlyPossibleValueInCol updates the Grid by Setting the value of any Cell
+// which is the only remaining Cell in its Column with a particular possible Value.
+func setOnlyPossibleValueInCol(grid *Grid) []Step {
+
+	// Track the Steps applied to the Grid
+	steps := []Step{}
+
+	// Loop over the 9 Columns
+	for col := 0; col < 9; col++ {
+
+		// Loop over the possible Cell values (1-9)
+		for value := 1; value <= 9; value++ {
+			valueCount := 0
+			valueRow := -1
+
+			// Loop over the 9 Rows for the current Column
+			for row := 0; row < 9; row++ {
+
+				// Check the Cell's possible values for the current value
+				if grid.GetCell(row, col).IsPossibleValue(value) {
+					valueCount = valueCount + 1
+					valueRow = row
+				}
+
+				// If the value already exists then cease further checks
+				if valueCount > 1 {
+					valueRow = -1
+					break
+				}
+			}
+
+			// If only a single Cell in the Column has the possible value, then set it!
+			if valueCount == 1 {
+				steps = append(steps, placeHiddenSingle(grid, TechniqueHiddenSingleCol, valueRow, col, value, House{Kind: ColHouse, Index: col}))
+			}
+		}
+	}
+
+	// Return the Steps applied
+	return steps
+}
+
+// setOnlyPossibleValueInGroup updates the Grid by Setting the value of any Cell
+// which is the only remaining Cell in its Group with a particular possible Value.
+func setOnlyPossibleValueInGroup(grid *Grid) []Step {
+
+	// Track the Steps applied to the Grid
+	steps := []Step{}
+
+	// Loop over the 9 (sub) Groups selecting the upper-left Cell of each Group
+	forEachGroup(func(groupRow int, groupCol int) {
+
+		// Loop over the possible Cell values (1-9)
+		for value := 1; value <= 9; value++ {
+			valueCount := 0
+			valueRow := -1
+			valueCol := -1
+
+		outerLoop:
+
+			// Loop over the 9 Cells in the Group
+			for groupCellRow := groupRow; groupCellRow < groupRow+3; groupCellRow++ {
+				for groupCellCol := groupCol; groupCellCol < groupCol+3; groupCellCol++ {
+
+					// Check the Cell's possible values for the current value
+					if grid.GetCell(groupCellRow, groupCellCol).IsPossibleValue(value) {
+						valueCount = valueCount + 1
+						valueRow = groupCellRow
+						valueCol = groupCellCol
+					}
+
+					// If the value already exists then cease further checks
+					if valueCount > 1 {
+						valueRow = -1
+						valueCol = -1
+						break outerLoop
+					}
+				}
+			}
+
+			// If only a single Cell in the Group has the possible value, then set it!
+			if valueCount == 1 {
+				steps = append(steps, placeHiddenSingle(grid, TechniqueHiddenSingleGroup, valueRow, valueCol, value, House{Kind: GroupHouse, Index: groupRow + groupCol/3}))
+			}
+		}
+	})
+
+	// Return the Steps applied
+	return steps
+}
+
+// forEachGroup calls visit with the upper-left row/col of each of the 9 (sub)
+// Groups, left to right, top to bottom.
+func forEachGroup(visit func(groupRow int, groupCol int)) {
+	for groupRow := 0; groupRow <= 6; groupRow = groupRow + 3 {
+		for groupCol := 0; groupCol <= 6; groupCol = groupCol + 3 {
+			visit(groupRow, groupCol)
+		}
+	}
+}
+
+// placeHiddenSingle sets the value of the Cell at row/col, the only Cell in the
+// House where it is possible, and returns the Step describing the placement.
+func placeHiddenSingle(grid *Grid, technique string, row int, col int, value int, house House) Step {
+	return placeValue(grid, technique, row, col, value, fmt.Sprintf("Only cell in %s with possible value %d", house, value), house)
+}
+
+// placeValue sets the value of the Cell at row/col and returns the Step
+// describing the placement, within the House(s) which forced it.
+func placeValue(grid *Grid, technique string, row int, col int, value int, reason string, houses ...House) Step {
+	step := Step{
+		Technique:  technique,
+		Placements: []Candidate{newCandidate(row, col, value)},
+		Cells:      []Position{{Row: row, Col: col}},
+		Houses:     houses,
+		Reason:     reason,
+	}
+	applyStep(grid, step)
+	return step
+}
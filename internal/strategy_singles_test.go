@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSinglesStrategies(t *testing.T) {
+
+	// Define The TestCases
+	testCases := map[string]struct {
+		strategy   Strategy
+		name       string
+		difficulty float64
+	}{
+		"Naked Single":          {strategy: &nakedSingleStrategy{}, name: TechniqueNakedSingle, difficulty: 2.3},
+		"Hidden Single (Row)":   {strategy: &hiddenSingleStrategy{kind: RowHouse}, name: TechniqueHiddenSingleRow, difficulty: 1.5},
+		"Hidden Single (Col)":   {strategy: &hiddenSingleStrategy{kind: ColHouse}, name: TechniqueHiddenSingleCol, difficulty: 1.5},
+		"Hidden Single (Group)": {strategy: &hiddenSingleStrategy{kind: GroupHouse}, name: TechniqueHiddenSingleGroup, difficulty: 1.2},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			assert.Equal(t, testCase.name, testCase.strategy.Name())
+			assert.Equal(t, testCase.difficulty, testCase.strategy.Difficulty())
+
+			// Every Step places exactly one (correct) value using the technique
+			grid := testGrid()
+			steps := testCase.strategy.Apply(grid)
+			assert.NotEmpty(t, steps)
+			assertValidSteps(t, testSolvedPuzzle, steps)
+			for _, step := range steps {
+				assert.Equal(t, testCase.name, step.Technique)
+				assert.Len(t, step.Placements, 1)
+				assert.Equal(t, step.Placements[0].Value, grid.GetCell(step.Placements[0].Row, step.Placements[0].Col).GetValue())
+				assert.Equal(t, []Position{step.Placements[0].Position}, step.Cells)
+				for _, house := range step.Houses {
+					assert.True(t, house.contains(step.Placements[0].Position))
+				}
+			}
+		})
+	}
+}
+
+func TestForEachGroup(t *testing.T) {
+	origins := []Position{}
+	forEachGroup(func(groupRow int, groupCol int) {
+		origins = append(origins, Position{Row: groupRow, Col: groupCol})
+	})
+	assert.Equal(t, []Position{{0, 0}, {0, 3}, {0, 6}, {3, 0}, {3, 3}, {3, 6}, {6, 0}, {6, 3}, {6, 6}}, origins)
+}
+
+func TestPlaceHiddenSingle(t *testing.T) {
+	grid := testGrid()
+	step := placeHiddenSingle(grid, TechniqueHiddenSingleGroup, 0, 2, 9, House{Kind: GroupHouse, Index: 0})
+	assert.Equal(t, "Only cell in box 1 with possible value 9", step.Reason)
+	assert.Equal(t, []House{{Kind: GroupHouse, Index: 0}}, step.Houses)
+	assert.Equal(t, 9, grid.GetCell(0, 2).GetValue())
+}
@@ -0,0 +1,225 @@
+package internal
+
+import "fmt"
+
+// Names of the subset techniques, as reported in a SolveResult.
+const (
+	TechniqueNakedPair    = "Naked Pair"    // 2 Cells in a House share exactly 2 possible values
+	TechniqueNakedTriple  = "Naked Triple"  // 3 Cells in a House share exactly 3 possible values
+	TechniqueNakedQuad    = "Naked Quad"    // 4 Cells in a House share exactly 4 possible values
+	TechniqueHiddenPair   = "Hidden Pair"   // 2 values in a House are confined to the same 2 Cells
+	TechniqueHiddenTriple = "Hidden Triple" // 3 values in a House are confined to the same 3 Cells
+	TechniqueHiddenQuad   = "Hidden Quad"   // 4 values in a House are confined to the same 4 Cells
+)
+
+// nakedSubsetStrategy eliminates values from the Cells of a House when N other
+// Cells in the House have only the same N possible values between them, since
+// those N values must occupy those N Cells.
+type nakedSubsetStrategy struct {
+	size int // Number of Cells in the subset (2-4)
+}
+
+func (n *nakedSubsetStrategy) Name() string {
+	switch n.size {
+	case 2:
+		return TechniqueNakedPair
+	case 3:
+		return TechniqueNakedTriple
+	default:
+		return TechniqueNakedQuad
+	}
+}
+
+func (n *nakedSubsetStrategy) Difficulty() float64 {
+	switch n.size {
+	case 2:
+		return 3.0
+	case 3:
+		return 3.6
+	default:
+		return 5.0
+	}
+}
+
+func (n *nakedSubsetStrategy) Apply(grid *Grid) []Step {
+
+	// Loop over every Row, Column, and Group
+	for _, house := range allHouses {
+
+		// Collect the unknown Cells which could belong to a subset of this size
+		subsetCells := []Position{}
+		for _, position := range house.Positions() {
+			count := grid.GetCell(position.Row, position.Col).possibleMask().count()
+			if count >= 2 && count <= n.size {
+				subsetCells = append(subsetCells, position)
+			}
+		}
+
+		// Look for N Cells which have only N possible values between them
+		var step *Step
+		combinations(len(subsetCells), n.size, func(indexes []int) bool {
+			subset := make([]Position, n.size)
+			union := candidateMask(0)
+			for index, cellIndex := range indexes {
+				subset[index] = subsetCells[cellIndex]
+				union |= grid.GetCell(subset[index].Row, subset[index].Col).possibleMask()
+			}
+			if union.count() != n.size {
+				return false
+			}
+
+			// Eliminate those values from the other Cells in the House
+			eliminations := eliminateFromHouse(grid, house, subset, union)
+			if len(eliminations) == 0 {
+				return false
+			}
+			step = &Step{
+				Technique:    n.Name(),
+				Eliminations: eliminations,
+				Cells:        subset,
+				Houses:       []House{house},
+				Reason: fmt.Sprintf("Values %s are confined to cells %s in %s",
+					union, formatPositions(subset), house),
+			}
+			return true
+		})
+
+		// Apply the first subset found which makes progress
+		if step != nil {
+			applyStep(grid, *step)
+			return []Step{*step}
+		}
+	}
+
+	// No subset made progress
+	return nil
+}
+
+// hiddenSubsetStrategy eliminates the other possible values of N Cells in a House
+// when N values can only go in those same N Cells, since those N Cells must hold
+// those N values.  This generalizes the hidden single (N=1) to larger subsets.
+type hiddenSubsetStrategy struct {
+	size int // Number of values in the subset (2-4)
+}
+
+func (h *hiddenSubsetStrategy) Name() string {
+	switch h.size {
+	case 2:
+		return TechniqueHiddenPair
+	case 3:
+		return TechniqueHiddenTriple
+	default:
+		return TechniqueHiddenQuad
+	}
+}
+
+func (h *hiddenSubsetStrategy) Difficulty() float64 {
+	switch h.size {
+	case 2:
+		return 3.4
+	case 3:
+		return 4.0
+	default:
+		return 5.4
+	}
+}
+
+func (h *hiddenSubsetStrategy) Apply(grid *Grid) []Step {
+
+	// Loop over every Row, Column, and Group
+	for _, house := range allHouses {
+		positions := house.Positions()
+
+		// Collect the values which could belong to a subset of this size,
+		// along with the Cells (by offset in the House) where each is possible
+		subsetValues := []int{}
+		valueCells := map[int]candidateMask{}
+		for value := 1; value <= 9; value++ {
+			cells := candidateMask(0)
+			for offset, position := range positions {
+				if grid.GetCell(position.Row, position.Col).IsPossibleValue(value) {
+					cells |= 1 << offset
+				}
+			}
+			if cells.count() >= 2 && cells.count() <= h.size {
+				subsetValues = append(subsetValues, value)
+				valueCells[value] = cells
+			}
+		}
+
+		// Look for N values which can only go in the same N Cells
+		var step *Step
+		combinations(len(subsetValues), h.size, func(indexes []int) bool {
+			values := candidateMask(0)
+			cells := candidateMask(0)
+			for _, valueIndex := range indexes {
+				values |= maskOf(subsetValues[valueIndex])
+				cells |= valueCells[subsetValues[valueIndex]]
+			}
+			if cells.count() != h.size {
+				return false
+			}
+
+			// Eliminate every other possible value from those Cells
+			subset := []Position{}
+			eliminations := []Candidate{}
+			for offset, position := range positions {
+				if cells&(1<<offset) == 0 {
+					continue
+				}
+				subset = append(subset, position)
+				others := grid.GetCell(position.Row, position.Col).possibleMask() &^ values
+				for _, value := range others.values() {
+					eliminations = append(eliminations, newCandidate(position.Row, position.Col, value))
+				}
+			}
+			if len(eliminations) == 0 {
+				return false
+			}
+			step = &Step{
+				Technique:    h.Name(),
+				Eliminations: eliminations,
+				Cells:        subset,
+				Houses:       []House{house},
+				Reason: fmt.Sprintf("Values %s can only go in cells %s of %s",
+					values, formatPositions(subset), house),
+			}
+			return true
+		})
+
+		// Apply the first subset found which makes progress
+		if step != nil {
+			applyStep(grid, *step)
+			return []Step{*step}
+		}
+	}
+
+	// No subset made progress
+	return nil
+}
+
+// eliminateFromHouse returns the eliminations of the values from every Cell in
+// the House, other than those excluded, where they are still possible.
+func eliminateFromHouse(grid *Grid, house House, excluded []Position, values candidateMask) []Candidate {
+	eliminations := []Candidate{}
+	for _, position := range house.Positions() {
+		if containsPosition(excluded, position) {
+			continue
+		}
+		possible := grid.GetCell(position.Row, position.Col).possibleMask() & values
+		for _, value := range possible.values() {
+			eliminations = append(eliminations, newCandidate(position.Row, position.Col, value))
+		}
+	}
+	return eliminations
+}
+
+// containsPosition returns whether the Position is in the list.
+func containsPosition(positions []Position, position Position) bool {
+	for _, existing := range positions {
+		if existing == position {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNakedSubsetStrategy(t *testing.T) {
+
+	// Define The TestCases, restricting Cells of the first Row to form a subset
+	testCases := map[string]struct {
+		size       int
+		name       string
+		difficulty float64
+		subset     map[int][]int // Possible values by Column
+	}{
+		"Pair":   {size: 2, name: TechniqueNakedPair, difficulty: 3.0, subset: map[int][]int{0: {1, 2}, 4: {1, 2}}},
+		"Triple": {size: 3, name: TechniqueNakedTriple, difficulty: 3.6, subset: map[int][]int{0: {1, 2}, 4: {2, 3}, 8: {1, 3}}},
+		"Quad":   {size: 4, name: TechniqueNakedQuad, difficulty: 5.0, subset: map[int][]int{0: {1, 2}, 3: {2, 3}, 6: {3, 4}, 8: {1, 4}}},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			strategy := &nakedSubsetStrategy{size: testCase.size}
+			assert.Equal(t, testCase.name, strategy.Name())
+			assert.Equal(t, testCase.difficulty, strategy.Difficulty())
+
+			// Create the subset in the first Row of an empty Grid
+			grid := NewGrid()
+			union := candidateMask(0)
+			for col, values := range testCase.subset {
+				restrictCandidates(grid, 0, col, values...)
+				union |= maskOf(values...)
+			}
+
+			// Verify the subset values are eliminated from the rest of the Row
+			steps := strategy.Apply(grid)
+			assert.Len(t, steps, 1)
+			assert.Equal(t, testCase.name, steps[0].Technique)
+			assert.Len(t, steps[0].Eliminations, (9-testCase.size)*testCase.size)
+			for col := 0; col < 9; col++ {
+				mask := grid.GetCell(0, col).possibleMask()
+				if _, inSubset := testCase.subset[col]; inSubset {
+					assert.Equal(t, maskOf(testCase.subset[col]...), mask)
+				} else {
+					assert.Equal(t, candidateMask(0), mask&union)
+				}
+			}
+
+			// Nothing further to eliminate in the Row (Groups remain)
+			for _, step := range strategy.Apply(grid) {
+				for _, elimination := range step.Eliminations {
+					assert.NotEqual(t, 0, elimination.Row)
+				}
+			}
+		})
+	}
+}
+
+func TestNakedSubsetStrategy_Puzzle(t *testing.T) {
+
+	// Define The TestCases, each stalling on singles before the subset is found
+	testCases := map[string]struct {
+		size     int
+		puzzle   string
+		solution string
+	}{
+		"Pair": {
+			size:     2,
+			puzzle:   ".2..13.......7..965......1........7..946...5..8...76....3.8.......59.2..249......",
+			solution: "926813745431275896578964312612359478794628153385147629153482967867591234249736581",
+		},
+		"Triple": {
+			size:     3,
+			puzzle:   "..4..61......5.68..3.2....5..7...........529.6...4.7...5..31...7....2.3....7.....",
+			solution: "594386172172459683836217945917628354483175296625943718259831467741562839368794521",
+		},
+		"Quad": {
+			size:     4,
+			puzzle:   "...6.....39.....41......3.......1...9....4.7.68....2...6..8..5..4.7....9.2.4.976.",
+			solution: "812643597395278641476915382257861934931524876684397215769182453548736129123459768",
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			strategy := &nakedSubsetStrategy{size: testCase.size}
+			grid := testSolveWith(testGridFromString(testCase.puzzle), testSinglesStrategies()...)
+			steps := strategy.Apply(grid)
+			assert.Len(t, steps, 1)
+			assert.NotEmpty(t, steps[0].Eliminations)
+			assertValidSteps(t, testCase.solution, steps)
+
+			// Nothing is found in a solved Grid
+			assert.Empty(t, strategy.Apply(testGridFromString(testCase.solution)))
+		})
+	}
+}
+
+func TestHiddenSubsetStrategy(t *testing.T) {
+
+	// Define The TestCases, confining values of the first Row to a subset of Columns
+	testCases := map[string]struct {
+		size       int
+		name       string
+		difficulty float64
+		cols       []int
+	}{
+		"Pair":   {size: 2, name: TechniqueHiddenPair, difficulty: 3.4, cols: []int{0, 4}},
+		"Triple": {size: 3, name: TechniqueHiddenTriple, difficulty: 4.0, cols: []int{0, 4, 8}},
+		"Quad":   {size: 4, name: TechniqueHiddenQuad, difficulty: 5.4, cols: []int{0, 3, 6, 8}},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			strategy := &hiddenSubsetStrategy{size: testCase.size}
+			assert.Equal(t, testCase.name, strategy.Name())
+			assert.Equal(t, testCase.difficulty, strategy.Difficulty())
+
+			// Confine the values 1..N to N Cells of the first Row of an empty Grid
+			grid := NewGrid()
+			for col := 0; col < 9; col++ {
+				if containsPosition(testCaseSubset(testCase.cols), Position{Row: 0, Col: col}) {
+					continue
+				}
+				for value := 1; value <= testCase.size; value++ {
+					grid.GetCell(0, col).EliminateValue(value)
+				}
+			}
+
+			// Verify every other value is eliminated from the subset Cells
+			steps := strategy.Apply(grid)
+			assert.Len(t, steps, 1)
+			assert.Equal(t, testCase.name, steps[0].Technique)
+			assert.Len(t, steps[0].Eliminations, (9-testCase.size)*testCase.size)
+			for _, col := range testCase.cols {
+				assert.Equal(t, testCase.size, grid.GetCell(0, col).possibleMask().count())
+			}
+			assert.Empty(t, strategy.Apply(grid))
+		})
+	}
+}
+
+func TestHiddenSubsetStrategy_Puzzle(t *testing.T) {
+
+	// Define The TestCases, each stalling on singles before the subset is found
+	testCases := map[string]struct {
+		size     int
+		puzzle   string
+		solution string
+	}{
+		"Pair": {
+			size:     2,
+			puzzle:   ".2..13.......7..965......1........7..946...5..8...76....3.8.......59.2..249......",
+			solution: "926813745431275896578964312612359478794628153385147629153482967867591234249736581",
+		},
+		"Triple": {
+			size:     3,
+			puzzle:   "...6.....39.....41......3.......1...9....4.7.68....2...6..8..5..4.7....9.2.4.976.",
+			solution: "812643597395278641476915382257861934931524876684397215769182453548736129123459768",
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			strategy := &hiddenSubsetStrategy{size: testCase.size}
+			grid := testSolveWith(testGridFromString(testCase.puzzle), testSinglesStrategies()...)
+			steps := strategy.Apply(grid)
+			assert.Len(t, steps, 1)
+			assert.NotEmpty(t, steps[0].Eliminations)
+			assertValidSteps(t, testCase.solution, steps)
+		})
+	}
+}
+
+// testCaseSubset returns the Positions of the Columns in the first Row.
+func testCaseSubset(cols []int) []Position {
+	positions := []Position{}
+	for _, col := range cols {
+		positions = append(positions, Position{Row: 0, Col: col})
+	}
+	return positions
+}
@@ -0,0 +1,215 @@
+package internal
+
+import "fmt"
+
+// Names of the Sue de Coq techniques, as reported in a SolveResult.
+const (
+	TechniqueSueDeCoq         = "Sue de Coq"          // Intersection Cells sharing their values with a line Cell and a Group Cell
+	TechniqueExtendedSueDeCoq = "Extended Sue de Coq" // Intersection Cells sharing their values with sets of line and Group Cells
+)
+
+// maxSueDeCoqSectorCells limits the number of Cells taken from the rest of the line,
+// or the rest of the Group, in an Extended Sue de Coq.
+const maxSueDeCoqSectorCells = 3
+
+// sueDeCoqSector is a set of unsolved Cells taken from the rest of a line or Group.
+type sueDeCoqSector struct {
+	positions []Position
+	values    candidateMask
+}
+
+// String returns the sector in the form "r1c7,r1c8 {1,2,5}".
+func (s sueDeCoqSector) String() string {
+	return fmt.Sprintf("%s %s", formatPositions(s.positions), s.values)
+}
+
+// sueDeCoqStrategy (two-sector disjoint subsets) looks for 2-3 unsolved Cells in the
+// intersection of a Group and a line with at least 2 more possible values than
+// Cells, along with Cells from the rest of the line and the rest of the Group
+// which have no possible values in common.  When the Cells hold only as many
+// values as there are Cells, every one of the values must be placed in them, as
+// the line Cells and the Group Cells cannot share a value.  The values of the
+// line Cells, and those of the intersection not in the Group Cells, are then
+// eliminated from the rest of the line, and likewise for the Group.  The basic
+// form takes a single Cell from each sector holding only intersection values,
+// while the extended form allows several Cells (an ALS) with extra values.
+type sueDeCoqStrategy struct {
+	extended bool // Whether to take several Cells, or extra values, from each sector
+}
+
+func (s *sueDeCoqStrategy) Name() string {
+	if s.extended {
+		return TechniqueExtendedSueDeCoq
+	}
+	return TechniqueSueDeCoq
+}
+
+func (s *sueDeCoqStrategy) Difficulty() float64 {
+	if s.extended {
+		return 5.3
+	}
+	return 5.0
+}
+
+func (s *sueDeCoqStrategy) Apply(grid *Grid) []Step {
+
+	// Loop over the intersections of each Group with its 3 Rows and 3 Columns
+	var step *Step
+	forEachGroup(func(groupRow int, groupCol int) {
+		group := House{Kind: GroupHouse, Index: groupRow + groupCol/3}
+		for offset := 0; offset < 3 && step == nil; offset++ {
+			for _, line := range []House{{Kind: RowHouse, Index: groupRow + offset}, {Kind: ColHouse, Index: groupCol + offset}} {
+				if step == nil {
+					step = s.findSueDeCoq(grid, group, line)
+				}
+			}
+		}
+	})
+	if step == nil {
+		return nil
+	}
+	applyStep(grid, *step)
+	return []Step{*step}
+}
+
+// findSueDeCoq returns the Step for the first Sue de Coq in the intersection of
+// the Group and line which makes progress, or nil.
+func (s *sueDeCoqStrategy) findSueDeCoq(grid *Grid, group House, line House) *Step {
+
+	// Split the unsolved Cells into the intersection and the rest of each House
+	inside, lineRest, groupRest := []Position{}, []Position{}, []Position{}
+	for _, position := range line.Positions() {
+		if grid.GetCell(position.Row, position.Col).GetValue() != 0 {
+			continue
+		}
+		if group.contains(position) {
+			inside = append(inside, position)
+		} else {
+			lineRest = append(lineRest, position)
+		}
+	}
+	for _, position := range group.Positions() {
+		if grid.GetCell(position.Row, position.Col).GetValue() == 0 && !line.contains(position) {
+			groupRest = append(groupRest, position)
+		}
+	}
+
+	// Try every set of 2-3 intersection Cells with at least 2 more values than Cells
+	for size := 2; size <= len(inside); size++ {
+		var step *Step
+		combinations(len(inside), size, func(indexes []int) bool {
+			cells := sueDeCoqSector{}
+			for _, index := range indexes {
+				cells.positions = append(cells.positions, inside[index])
+				cells.values |= grid.GetCell(inside[index].Row, inside[index].Col).possibleMask()
+			}
+			if cells.values.count() < size+2 {
+				return false
+			}
+
+			// Pair every line sector with every Group sector holding distinct values
+			for _, lineCells := range s.sectors(grid, lineRest, cells.values) {
+				for _, groupCells := range s.sectors(grid, groupRest, cells.values) {
+					if lineCells.values&groupCells.values != 0 {
+						continue
+					}
+					total := size + len(lineCells.positions) + len(groupCells.positions)
+					if (cells.values | lineCells.values | groupCells.values).count() != total {
+						continue
+					}
+
+					// Leave the basic form to the basic strategy
+					if s.extended == isBasicSueDeCoq(cells, lineCells, groupCells) {
+						continue
+					}
+					if step = sueDeCoqStep(grid, line, group, cells, lineCells, groupCells); step != nil {
+						return true
+					}
+				}
+			}
+			return false
+		})
+		if step != nil {
+			step.Technique = s.Name()
+			return step
+		}
+	}
+	return nil
+}
+
+// sectors returns every set of Cells from the rest of a House, each of which
+// shares a possible value with the intersection Cells, of up to 1 Cell or up to
+// maxSueDeCoqSectorCells Cells when extended.
+func (s *sueDeCoqStrategy) sectors(grid *Grid, rest []Position, values candidateMask) []sueDeCoqSector {
+	candidates := []Position{}
+	for _, position := range rest {
+		if grid.GetCell(position.Row, position.Col).possibleMask()&values != 0 {
+			candidates = append(candidates, position)
+		}
+	}
+	maxCells := 1
+	if s.extended {
+		maxCells = maxSueDeCoqSectorCells
+	}
+	sectors := []sueDeCoqSector{}
+	for size := 1; size <= maxCells; size++ {
+		combinations(len(candidates), size, func(indexes []int) bool {
+			sector := sueDeCoqSector{}
+			for _, index := range indexes {
+				sector.positions = append(sector.positions, candidates[index])
+				sector.values |= grid.GetCell(candidates[index].Row, candidates[index].Col).possibleMask()
+			}
+			sectors = append(sectors, sector)
+			return false
+		})
+	}
+	return sectors
+}
+
+// isBasicSueDeCoq returns whether each sector is a single Cell holding only values
+// of the intersection Cells.
+func isBasicSueDeCoq(cells sueDeCoqSector, lineCells sueDeCoqSector, groupCells sueDeCoqSector) bool {
+	return len(lineCells.positions) == 1 && len(groupCells.positions) == 1 &&
+		lineCells.values&^cells.values == 0 && groupCells.values&^cells.values == 0
+}
+
+// sueDeCoqStep returns the Step eliminating the values locked into the line from
+// the rest of the line, and those locked into the Group from the rest of the
+// Group, or nil if none are possible there.
+func sueDeCoqStep(grid *Grid, line House, group House, cells sueDeCoqSector, lineCells sueDeCoqSector, groupCells sueDeCoqSector) *Step {
+	lineValues := lineCells.values | (cells.values &^ groupCells.values)
+	groupValues := groupCells.values | (cells.values &^ lineCells.values)
+	lineExcluded := append(append([]Position{}, cells.positions...), lineCells.positions...)
+	groupExcluded := append(append([]Position{}, cells.positions...), groupCells.positions...)
+
+	// Cells in both Houses lose the values locked into either of them
+	eliminations := []Candidate{}
+	for _, house := range []House{line, group} {
+		for _, position := range house.Positions() {
+			if house == group && line.contains(position) {
+				continue
+			}
+			values := candidateMask(0)
+			if line.contains(position) && !containsPosition(lineExcluded, position) {
+				values |= lineValues
+			}
+			if group.contains(position) && !containsPosition(groupExcluded, position) {
+				values |= groupValues
+			}
+			possible := grid.GetCell(position.Row, position.Col).possibleMask() & values
+			for _, value := range possible.values() {
+				eliminations = append(eliminations, newCandidate(position.Row, position.Col, value))
+			}
+		}
+	}
+	if len(eliminations) == 0 {
+		return nil
+	}
+	return &Step{
+		Eliminations: eliminations,
+		Cells:        append(append(append([]Position{}, cells.positions...), lineCells.positions...), groupCells.positions...),
+		Houses:       []House{line, group},
+		Reason: fmt.Sprintf("Cells %s shared by %s and %s, with %s in the line and %s in the box, hold exactly %d values",
+			cells, line, group, lineCells, groupCells, (cells.values | lineCells.values | groupCells.values).count()),
+	}
+}
@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSueDeCoqStrategy(t *testing.T) {
+
+	// Define The TestCases, unsolving the listed Cells of the solved Grid
+	testCases := map[string]struct {
+		extended   bool
+		technique  string
+		difficulty float64
+		unsolved   map[Position][]int
+		eliminated []Candidate
+		reason     string
+	}{
+		"Basic": {
+			technique:  TechniqueSueDeCoq,
+			difficulty: 5.0,
+			unsolved: map[Position][]int{
+				{0, 0}: {2, 3, 4, 6}, {0, 1}: {2, 3, 4, 6}, {0, 6}: {2, 3}, {1, 0}: {4, 6},
+				{0, 7}: {2, 7}, {2, 1}: {6, 8},
+			},
+			eliminated: []Candidate{newCandidate(0, 7, 2), newCandidate(2, 1, 6)},
+			reason:     "Cells r1c1,r1c2 {2,3,4,6} shared by row 1 and box 1, with r1c7 {2,3} in the line and r2c1 {4,6} in the box, hold exactly 4 values",
+		},
+		"Extended": {
+			extended:   true,
+			technique:  TechniqueExtendedSueDeCoq,
+			difficulty: 5.3,
+			unsolved: map[Position][]int{
+				{0, 0}: {2, 3, 6}, {0, 1}: {2, 4, 6}, {0, 6}: {3, 7}, {0, 7}: {3, 7}, {1, 0}: {4, 6},
+				{0, 8}: {2, 8}, {2, 1}: {4, 8},
+			},
+			eliminated: []Candidate{newCandidate(0, 8, 2), newCandidate(2, 1, 4)},
+			reason:     "Cells r1c1,r1c2 {2,3,4,6} shared by row 1 and box 1, with r1c7,r1c8 {3,7} in the line and r2c1 {4,6} in the box, hold exactly 5 values",
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			strategy := &sueDeCoqStrategy{extended: testCase.extended}
+			assert.Equal(t, testCase.technique, strategy.Name())
+			assert.Equal(t, testCase.difficulty, strategy.Difficulty())
+
+			// Create the pattern in the solved Grid
+			grid := testGridFromString(testSolvedPuzzle)
+			for position, values := range testCase.unsolved {
+				unsolveCell(grid, position.Row, position.Col, values...)
+			}
+
+			// Verify the pattern is found and the values eliminated
+			steps := strategy.Apply(grid)
+			assert.Len(t, steps, 1)
+			assert.Equal(t, testCase.technique, steps[0].Technique)
+			assert.Equal(t, testCase.reason, steps[0].Reason)
+			assert.ElementsMatch(t, testCase.eliminated, steps[0].Eliminations)
+			assert.Empty(t, strategy.Apply(grid))
+		})
+	}
+}
+
+func TestSueDeCoqStrategy_Basic(t *testing.T) {
+
+	// A basic pattern is left to the basic strategy
+	grid := testGridFromString(testSolvedPuzzle)
+	for position, values := range map[Position][]int{{0, 0}: {2, 3, 4, 6}, {0, 1}: {2, 3, 4, 6}, {0, 6}: {2, 3}, {1, 0}: {4, 6}, {0, 7}: {2, 7}} {
+		unsolveCell(grid, position.Row, position.Col, values...)
+	}
+	assert.Empty(t, (&sueDeCoqStrategy{extended: true}).Apply(grid))
+	assert.NotEmpty(t, (&sueDeCoqStrategy{}).Apply(grid))
+}
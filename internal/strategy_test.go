@@ -0,0 +1,233 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testStrategy is a do-nothing Strategy for exercising the Registry.
+type testStrategy struct {
+	name string
+}
+
+func (t *testStrategy) Name() string            { return t.name }
+func (t *testStrategy) Difficulty() float64     { return 1.0 }
+func (t *testStrategy) Apply(grid *Grid) []Step { return nil }
+
+func TestDefaultRegistry(t *testing.T) {
+	registry := DefaultRegistry()
+	assert.Equal(t, []string{
+		TechniqueNakedSingle,
+		TechniqueHiddenSingleRow,
+		TechniqueHiddenSingleCol,
+		TechniqueHiddenSingleGroup,
+		TechniquePointing,
+		TechniqueClaiming,
+		TechniqueNakedPair,
+		TechniqueXWing,
+		TechniqueSkyscraper,
+		TechniqueTwoStringKite,
+		TechniqueHiddenPair,
+		TechniqueTurbotFish,
+		TechniqueFinnedXWing,
+		TechniqueEmptyRectangle,
+		TechniqueSimpleColoring,
+		TechniqueNakedTriple,
+		TechniqueSwordfish,
+		TechniqueHiddenTriple,
+		TechniqueMultiColoring,
+		TechniqueFinnedSwordfish,
+		TechniqueXYWing,
+		TechniqueXYZWing,
+		TechniqueUniqueRectangle1,
+		TechniqueUniqueRectangle2,
+		TechniqueUniqueRectangle4,
+		TechniqueUniqueRectangle5,
+		TechniqueWXYZWing,
+		TechniqueUniqueRectangle3,
+		TechniqueUniqueRectangle6,
+		TechniqueXChain,
+		TechniqueHiddenUniqueRectangle,
+		TechniqueXYChain,
+		TechniqueAIC,
+		TechniqueNakedQuad,
+		TechniqueSueDeCoq,
+		TechniqueGroupedAIC,
+		TechniqueJellyfish,
+		TechniqueExtendedSueDeCoq,
+		TechniqueHiddenQuad,
+		TechniqueFinnedJellyfish,
+		TechniqueALSXZ,
+		TechniqueBUGPlusOne,
+		TechniqueALSXYWing,
+		TechniqueDeathBlossom,
+		TechniqueSKLoop,
+		TechniqueJuniorExocet,
+		TechniquePatternOverlay,
+		TechniqueMSLS,
+		TechniqueCellForcingChain,
+		TechniqueUnitForcingChain,
+		TechniqueNishio,
+	}, registry.Names())
+	assert.Len(t, registry.Strategies(), len(registry.Names()))
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry := NewRegistry()
+	assert.Nil(t, registry.Register(&testStrategy{name: "A"}))
+	assert.Nil(t, registry.Register(&testStrategy{name: "B"}))
+	assert.EqualError(t, registry.Register(&testStrategy{name: "A"}), "strategy 'A' is already registered")
+	assert.Equal(t, []string{"A", "B"}, registry.Names())
+	assert.True(t, registry.IsEnabled("A"))
+	assert.False(t, registry.IsEnabled("C"))
+}
+
+func TestRegistry_EnableDisable(t *testing.T) {
+	registry := NewRegistry()
+	_ = registry.Register(&testStrategy{name: "A"})
+	_ = registry.Register(&testStrategy{name: "B"})
+	assert.Nil(t, registry.Disable("A"))
+	assert.False(t, registry.IsEnabled("A"))
+	assert.Equal(t, []Strategy{&testStrategy{name: "B"}}, registry.Strategies())
+	assert.Nil(t, registry.Enable("A"))
+	assert.True(t, registry.IsEnabled("A"))
+	assert.Len(t, registry.Strategies(), 2)
+	assert.EqualError(t, registry.Enable("C"), "strategy 'C' is not registered")
+	assert.EqualError(t, registry.Disable("C"), "strategy 'C' is not registered")
+}
+
+func TestRegistry_SetOrder(t *testing.T) {
+	registry := NewRegistry()
+	for _, name := range []string{"A", "B", "C", "D"} {
+		_ = registry.Register(&testStrategy{name: name})
+	}
+	assert.Nil(t, registry.SetOrder("C", "A"))
+	assert.Equal(t, []string{"C", "A", "B", "D"}, registry.Names())
+	assert.EqualError(t, registry.SetOrder("D", "E"), "strategy 'E' is not registered")
+	assert.Equal(t, []string{"C", "A", "B", "D"}, registry.Names())
+}
+
+func TestRegistry_byDifficulty(t *testing.T) {
+	registry := DefaultRegistry()
+	assert.Nil(t, registry.Disable(TechniqueXWing))
+	sorted := registry.byDifficulty()
+	assert.NotContains(t, sorted.Names(), TechniqueXWing)
+	assert.Len(t, sorted.Names(), len(registry.Strategies()))
+	assert.Equal(t, TechniqueHiddenSingleGroup, sorted.Names()[0])
+	strategies := sorted.Strategies()
+	for index := 1; index < len(strategies); index++ {
+		assert.LessOrEqual(t, strategies[index-1].Difficulty(), strategies[index].Difficulty())
+	}
+	assert.Equal(t, TechniqueNakedSingle, registry.Names()[0])
+}
+
+func TestStrategyTier(t *testing.T) {
+	assert.Equal(t, "standard", StandardTier.String())
+	assert.Equal(t, "exotic", ExoticTier.String())
+	assert.Equal(t, "StrategyTier(7)", StrategyTier(7).String())
+	assert.Equal(t, StandardTier, strategyTier(&nakedSingleStrategy{}))
+
+	// Verify each tier can be parsed from its name
+	for _, tier := range []StrategyTier{StandardTier, ExoticTier} {
+		parsed, err := ParseStrategyTier(tier.String())
+		assert.NoError(t, err)
+		assert.Equal(t, tier, parsed)
+	}
+	_, err := ParseStrategyTier("extreme")
+	assert.EqualError(t, err, "unknown strategy tier 'extreme' must be one of standard,exotic")
+}
+
+// assertValidSteps verifies that each Step agrees with the solution of the
+// puzzle, never placing a wrong value nor eliminating the correct one.
+func assertValidSteps(t *testing.T, solution string, steps []Step) {
+	t.Helper()
+	for _, step := range steps {
+		for _, placement := range step.Placements {
+			expect := int(solution[placement.Row*9+placement.Col] - '0')
+			assert.Equal(t, expect, placement.Value, "%s placed a wrong value: %s", step.Technique, step.Reason)
+		}
+		for _, elimination := range step.Eliminations {
+			expect := int(solution[elimination.Row*9+elimination.Col] - '0')
+			assert.NotEqual(t, expect, elimination.Value, "%s eliminated the solution: %s", step.Technique, step.Reason)
+		}
+	}
+}
+
+// testSolveWith repeatedly applies the Strategies in order to the Grid, starting
+// over from the first whenever one makes progress, until none make progress.
+func testSolveWith(grid *Grid, strategies ...Strategy) *Grid {
+	for progress := true; progress; {
+		progress = false
+		for _, strategy := range strategies {
+			if len(strategy.Apply(grid)) > 0 {
+				progress = true
+				break
+			}
+		}
+	}
+	return grid
+}
+
+// testCollectSteps solves the Grid as far as possible with singles and the target
+// Strategy, and returns the Steps made by the target Strategy.
+func testCollectSteps(grid *Grid, target Strategy) []Step {
+	collected := []Step{}
+	for progress := true; progress; {
+		progress = false
+		for _, strategy := range append(testSinglesStrategies(), target) {
+			steps := strategy.Apply(grid)
+			if strategy == target {
+				collected = append(collected, steps...)
+			}
+			if len(steps) > 0 {
+				progress = true
+				break
+			}
+		}
+	}
+	return collected
+}
+
+// testSinglesStrategies returns the Strategies for naked and hidden singles.
+func testSinglesStrategies() []Strategy {
+	return []Strategy{
+		&nakedSingleStrategy{},
+		&hiddenSingleStrategy{kind: RowHouse},
+		&hiddenSingleStrategy{kind: ColHouse},
+		&hiddenSingleStrategy{kind: GroupHouse},
+	}
+}
+
+// restrictCandidates eliminates every possible value of the Cell at row/col
+// other than those specified.
+func restrictCandidates(grid *Grid, row int, col int, values ...int) {
+	keep := maskOf(values...)
+	for value := 1; value <= 9; value++ {
+		if !keep.has(value) {
+			grid.GetCell(row, col).EliminateValue(value)
+		}
+	}
+}
+
+// confineValue eliminates the value from every Cell of each House other than the
+// Positions specified for it.
+func confineValue(grid *Grid, value int, houses map[House][]Position) {
+	for house, positions := range houses {
+		for _, position := range house.Positions() {
+			if !containsPosition(positions, position) {
+				grid.GetCell(position.Row, position.Col).EliminateValue(value)
+			}
+		}
+	}
+}
+
+// unsolveCell clears the value of the Cell at row/col, leaving only the specified
+// values possible.
+func unsolveCell(grid *Grid, row int, col int, values ...int) {
+	cell := grid.GetCell(row, col)
+	cell.value = 0
+	for _, value := range values {
+		cell.possible[value-1] = true
+	}
+}
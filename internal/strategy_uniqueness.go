@@ -0,0 +1,453 @@
+package internal
+
+import "fmt"
+
+// Names of the uniqueness techniques, as reported in a SolveResult.
+const (
+	TechniqueUniqueRectangle1      = "Unique Rectangle Type 1" // Only one corner has extra values
+	TechniqueUniqueRectangle2      = "Unique Rectangle Type 2" // 2 corners in a line share a single extra value
+	TechniqueUniqueRectangle3      = "Unique Rectangle Type 3" // Extra values of 2 corners form a naked subset
+	TechniqueUniqueRectangle4      = "Unique Rectangle Type 4" // A rectangle value is confined to 2 corners in a line
+	TechniqueUniqueRectangle5      = "Unique Rectangle Type 5" // Diagonal or 3 corners share a single extra value
+	TechniqueUniqueRectangle6      = "Unique Rectangle Type 6" // A rectangle value forms an X-Wing on the rectangle
+	TechniqueHiddenUniqueRectangle = "Hidden Unique Rectangle" // A rectangle value is confined to the lines of a corner
+	TechniqueBUGPlusOne            = "BUG+1"                   // Every unsolved Cell is bivalue except one
+)
+
+// rectangle is 4 unsolved Cells at the corners of 2 Rows and 2 Columns spanning
+// exactly 2 Groups, in which the values {a,b} are possible in every corner.  If
+// every corner were reduced to {a,b} the values could be swapped, giving 2
+// solutions (a "deadly pattern"), so in a unique puzzle at least one corner holds
+// another value.
+type rectangle struct {
+	corners [4]Position      // Ordered top left, top right, bottom left, bottom right
+	values  candidateMask    // The values {a,b}
+	extras  [4]candidateMask // The other possible values of each corner
+}
+
+// String returns the rectangle in the form "{1,2} in r1c1,r1c5,r5c1,r5c5".
+func (r rectangle) String() string {
+	return fmt.Sprintf("%s in %s", r.values, formatPositions(r.corners[:]))
+}
+
+// floor returns the indexes of the corners without extra values.
+func (r rectangle) floor() []int {
+	floor := []int{}
+	for index, extras := range r.extras {
+		if extras == 0 {
+			floor = append(floor, index)
+		}
+	}
+	return floor
+}
+
+// roof returns the indexes of the corners with extra values, and the positions.
+func (r rectangle) roof() ([]int, []Position) {
+	indexes, positions := []int{}, []Position{}
+	for index, extras := range r.extras {
+		if extras != 0 {
+			indexes = append(indexes, index)
+			positions = append(positions, r.corners[index])
+		}
+	}
+	return indexes, positions
+}
+
+// isDiagonal returns whether the corners with the 2 indexes are opposite each
+// other rather than sharing a line.
+func isDiagonal(first int, second int) bool {
+	return first+second == 3
+}
+
+// forEachRectangle calls visit for every rectangle in the Grid, stopping as soon
+// as visit returns a Step, which is then returned.
+func forEachRectangle(grid *Grid, visit func(r rectangle) *Step) *Step {
+	for row1 := 0; row1 < 9; row1++ {
+		for row2 := row1 + 1; row2 < 9; row2++ {
+			for col1 := 0; col1 < 9; col1++ {
+				for col2 := col1 + 1; col2 < 9; col2++ {
+
+					// The corners must span exactly 2 Groups
+					if (row1/3 == row2/3) == (col1/3 == col2/3) {
+						continue
+					}
+					r := rectangle{corners: [4]Position{{row1, col1}, {row1, col2}, {row2, col1}, {row2, col2}}}
+					common := candidateMask(0x1ff)
+					masks := [4]candidateMask{}
+					for index, corner := range r.corners {
+						masks[index] = grid.GetCell(corner.Row, corner.Col).possibleMask()
+						common &= masks[index]
+					}
+
+					// Try every pair of values possible in all the corners
+					values := common.values()
+					for first := range values {
+						for second := first + 1; second < len(values); second++ {
+							r.values = maskOf(values[first], values[second])
+							for index := range masks {
+								r.extras[index] = masks[index] &^ r.values
+							}
+							if step := visit(r); step != nil {
+								return step
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// uniqueRectangleStrategy eliminates values which would otherwise leave a
+// rectangle as a deadly pattern, with the different types depending on which
+// corners hold extra values.  Only valid for puzzles with a unique solution.
+type uniqueRectangleStrategy struct {
+	technique string
+}
+
+func (u *uniqueRectangleStrategy) Name() string             { return u.technique }
+func (u *uniqueRectangleStrategy) RequiresUniqueness() bool { return true }
+func (u *uniqueRectangleStrategy) Difficulty() float64 {
+	return map[string]float64{
+		TechniqueUniqueRectangle1:      4.5,
+		TechniqueUniqueRectangle2:      4.6,
+		TechniqueUniqueRectangle3:      4.7,
+		TechniqueUniqueRectangle4:      4.6,
+		TechniqueUniqueRectangle5:      4.6,
+		TechniqueUniqueRectangle6:      4.7,
+		TechniqueHiddenUniqueRectangle: 4.8,
+	}[u.technique]
+}
+
+func (u *uniqueRectangleStrategy) Apply(grid *Grid) []Step {
+	finders := map[string]func(*Grid, rectangle) *Step{
+		TechniqueUniqueRectangle1:      uniqueRectangle1,
+		TechniqueUniqueRectangle2:      uniqueRectangle2,
+		TechniqueUniqueRectangle3:      uniqueRectangle3,
+		TechniqueUniqueRectangle4:      uniqueRectangle4,
+		TechniqueUniqueRectangle5:      uniqueRectangle5,
+		TechniqueUniqueRectangle6:      uniqueRectangle6,
+		TechniqueHiddenUniqueRectangle: hiddenUniqueRectangle,
+	}
+	step := forEachRectangle(grid, func(r rectangle) *Step {
+		step := finders[u.technique](grid, r)
+		if step != nil {
+			step.Cells = append(append([]Position{}, r.corners[:]...), step.Cells...)
+		}
+		return step
+	})
+	if step == nil {
+		return nil
+	}
+	step.Technique = u.technique
+	applyStep(grid, *step)
+	return []Step{*step}
+}
+
+// uniqueRectangle1 eliminates the rectangle values from the only corner with
+// extra values.
+func uniqueRectangle1(grid *Grid, r rectangle) *Step {
+	if len(r.floor()) != 3 {
+		return nil
+	}
+	_, roof := r.roof()
+	return &Step{
+		Eliminations: append(candidatesOf(roof, r.values.values()[0]), candidatesOf(roof, r.values.values()[1])...),
+		Reason:       fmt.Sprintf("Unique rectangle %s has extra values only in %s", r, roof[0]),
+	}
+}
+
+// uniqueRectangle2 eliminates the single extra value shared by 2 corners in a
+// line from every Cell which sees both, as one of them must hold it.
+func uniqueRectangle2(grid *Grid, r rectangle) *Step {
+	floor := r.floor()
+	if len(floor) != 2 || isDiagonal(floor[0], floor[1]) {
+		return nil
+	}
+	return sharedExtraStep(grid, r)
+}
+
+// uniqueRectangle5 eliminates the single extra value shared by 2 diagonal corners,
+// or by 3 corners, from every Cell which sees them all, as one of them must hold it.
+func uniqueRectangle5(grid *Grid, r rectangle) *Step {
+	floor := r.floor()
+	if !(len(floor) == 1 || (len(floor) == 2 && isDiagonal(floor[0], floor[1]))) {
+		return nil
+	}
+	return sharedExtraStep(grid, r)
+}
+
+// sharedExtraStep returns the Step eliminating the single extra value shared by
+// every corner with extra values, or nil if they do not share a single extra value
+// or it makes no progress.
+func sharedExtraStep(grid *Grid, r rectangle) *Step {
+	roofIndexes, roof := r.roof()
+	extra := r.extras[roofIndexes[0]]
+	for _, index := range roofIndexes {
+		if r.extras[index] != extra || extra.count() != 1 {
+			return nil
+		}
+	}
+	value := extra.values()[0]
+	eliminations := []Candidate{}
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			position := Position{Row: row, Col: col}
+			if grid.GetCell(row, col).IsPossibleValue(value) && seesAll(position, roof) {
+				eliminations = append(eliminations, newCandidate(row, col, value))
+			}
+		}
+	}
+	if len(eliminations) == 0 {
+		return nil
+	}
+	return &Step{
+		Eliminations: eliminations,
+		Reason:       fmt.Sprintf("Unique rectangle %s has extra value %d in cells %s, one of which must hold it", r, value, formatPositions(roof)),
+	}
+}
+
+// uniqueRectangle3 treats the extra values of 2 corners in a line as a single
+// pseudo Cell, which with other Cells of a House they share forms a naked subset,
+// eliminating the subset's values from the rest of the House.
+func uniqueRectangle3(grid *Grid, r rectangle) *Step {
+	floor := r.floor()
+	if len(floor) != 2 || isDiagonal(floor[0], floor[1]) {
+		return nil
+	}
+	roofIndexes, roof := r.roof()
+	extras := r.extras[roofIndexes[0]] | r.extras[roofIndexes[1]]
+	for _, house := range sharedHouses(roof[0], roof[1]) {
+
+		// Collect the other unsolved Cells of the House
+		others := []Position{}
+		for _, position := range house.Positions() {
+			if grid.GetCell(position.Row, position.Col).GetValue() == 0 && !containsPosition(roof, position) {
+				others = append(others, position)
+			}
+		}
+
+		// Look for N other Cells with only N+1 values between them and the extras
+		for size := 1; size <= 3; size++ {
+			var step *Step
+			combinations(len(others), size, func(indexes []int) bool {
+				subset := extras
+				cells := []Position{}
+				for _, index := range indexes {
+					cells = append(cells, others[index])
+					subset |= grid.GetCell(others[index].Row, others[index].Col).possibleMask()
+				}
+				if subset.count() != size+1 {
+					return false
+				}
+				eliminations := eliminateFromHouse(grid, house, append(cells, roof...), subset)
+				if len(eliminations) == 0 {
+					return false
+				}
+				step = &Step{
+					Eliminations: eliminations,
+					Cells:        cells,
+					Houses:       []House{house},
+					Reason: fmt.Sprintf("Unique rectangle %s has extra values %s in cells %s, forming naked subset %s with cells %s in %s",
+						r, extras, formatPositions(roof), subset, formatPositions(cells), house),
+				}
+				return true
+			})
+			if step != nil {
+				return step
+			}
+		}
+	}
+	return nil
+}
+
+// uniqueRectangle4 eliminates the other rectangle value from 2 corners in a line
+// when one of the rectangle values is confined to them in a House they share.
+func uniqueRectangle4(grid *Grid, r rectangle) *Step {
+	floor := r.floor()
+	if len(floor) != 2 || isDiagonal(floor[0], floor[1]) {
+		return nil
+	}
+	_, roof := r.roof()
+	for _, house := range sharedHouses(roof[0], roof[1]) {
+		for _, value := range r.values.values() {
+			if !isConfinedTo(grid, value, house, roof) {
+				continue
+			}
+			other := (r.values &^ maskOf(value)).values()[0]
+			return &Step{
+				Eliminations: candidatesOf(roof, other),
+				Houses:       []House{house},
+				Reason: fmt.Sprintf("Unique rectangle %s, where value %d in %s is confined to cells %s",
+					r, value, house, formatPositions(roof)),
+			}
+		}
+	}
+	return nil
+}
+
+// uniqueRectangle6 eliminates a rectangle value from 2 diagonal corners with extra
+// values, when the value is confined to the rectangle in both of its Rows or both
+// of its Columns (an X-Wing), as it would otherwise leave a deadly pattern.
+func uniqueRectangle6(grid *Grid, r rectangle) *Step {
+	floor := r.floor()
+	if len(floor) != 2 || !isDiagonal(floor[0], floor[1]) {
+		return nil
+	}
+	_, roof := r.roof()
+	for _, value := range r.values.values() {
+		for _, kind := range []HouseKind{RowHouse, ColHouse} {
+			lines := [2]House{{Kind: kind, Index: r.corners[0].Row}, {Kind: kind, Index: r.corners[3].Row}}
+			if kind == ColHouse {
+				lines = [2]House{{Kind: kind, Index: r.corners[0].Col}, {Kind: kind, Index: r.corners[3].Col}}
+			}
+			if !isConfinedTo(grid, value, lines[0], r.corners[:]) || !isConfinedTo(grid, value, lines[1], r.corners[:]) {
+				continue
+			}
+			return &Step{
+				Eliminations: candidatesOf(roof, value),
+				Houses:       lines[:],
+				Reason: fmt.Sprintf("Unique rectangle %s, where value %d in %s and %s is confined to the rectangle",
+					r, value, lines[0], lines[1]),
+			}
+		}
+	}
+	return nil
+}
+
+// hiddenUniqueRectangle eliminates a rectangle value from the corner opposite a
+// bivalue corner, when the other rectangle value is confined to the rectangle in
+// both the Row and the Column of that corner.
+func hiddenUniqueRectangle(grid *Grid, r rectangle) *Step {
+	for _, index := range r.floor() {
+		opposite := r.corners[3-index]
+		lines := []House{{Kind: RowHouse, Index: opposite.Row}, {Kind: ColHouse, Index: opposite.Col}}
+		for _, value := range r.values.values() {
+			other := (r.values &^ maskOf(value)).values()[0]
+			if !isConfinedTo(grid, value, lines[0], r.corners[:]) || !isConfinedTo(grid, value, lines[1], r.corners[:]) {
+				continue
+			}
+			return &Step{
+				Eliminations: []Candidate{newCandidate(opposite.Row, opposite.Col, other)},
+				Houses:       lines,
+				Reason: fmt.Sprintf("Unique rectangle %s, where value %d in %s and %s is confined to the rectangle",
+					r, value, lines[0], lines[1]),
+			}
+		}
+	}
+	return nil
+}
+
+// isConfinedTo returns whether every possible Cell for the value in the House is
+// one of the Positions.
+func isConfinedTo(grid *Grid, value int, house House, positions []Position) bool {
+	for _, position := range house.Positions() {
+		if grid.GetCell(position.Row, position.Col).IsPossibleValue(value) && !containsPosition(positions, position) {
+			return false
+		}
+	}
+	return true
+}
+
+// sharedHouses returns the Houses containing both Positions.
+func sharedHouses(first Position, second Position) []House {
+	houses := []House{}
+	for _, house := range allHouses {
+		if house.contains(first) && house.contains(second) {
+			houses = append(houses, house)
+		}
+	}
+	return houses
+}
+
+// bugPlusOneStrategy places a value in the only unsolved Cell with 3 possible
+// values when every other unsolved Cell is bivalue (a Binary Universal Grave plus
+// one).  Without the value the Grid would be a deadly pattern in which every value
+// is possible exactly twice in each House, so it is the value possible 3 times in
+// the Cell's Row, Column, and Group.  Only valid for puzzles with a unique solution.
+type bugPlusOneStrategy struct{}
+
+func (b *bugPlusOneStrategy) Name() string             { return TechniqueBUGPlusOne }
+func (b *bugPlusOneStrategy) Difficulty() float64      { return 5.6 }
+func (b *bugPlusOneStrategy) RequiresUniqueness() bool { return true }
+func (b *bugPlusOneStrategy) Apply(grid *Grid) []Step {
+
+	// Find the only unsolved Cell which is not bivalue
+	var extra *Position
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			switch grid.GetCell(row, col).possibleMask().count() {
+			case 0, 2:
+			case 3:
+				if extra != nil {
+					return nil
+				}
+				extra = &Position{Row: row, Col: col}
+			default:
+				return nil
+			}
+		}
+	}
+	if extra == nil {
+		return nil
+	}
+
+	// Place the value which is possible 3 times in each of the Cell's Houses
+	mask := grid.GetCell(extra.Row, extra.Col).possibleMask()
+	for _, value := range mask.values() {
+		triple, extraHouses := true, []House{}
+		for _, house := range allHouses {
+			if !house.contains(*extra) {
+				continue
+			}
+			extraHouses = append(extraHouses, house)
+			if countPossible(grid, value, house) != 3 {
+				triple = false
+			}
+		}
+		if !triple || !isBUGPlusOne(grid, *extra, value) {
+			continue
+		}
+		step := Step{
+			Technique:  TechniqueBUGPlusOne,
+			Placements: []Candidate{newCandidate(extra.Row, extra.Col, value)},
+			Cells:      []Position{*extra},
+			Houses:     extraHouses,
+			Reason: fmt.Sprintf("Every unsolved cell except %s %s is bivalue, so it must hold value %d to avoid a deadly pattern",
+				extra, mask, value),
+		}
+		applyStep(grid, step)
+		return []Step{step}
+	}
+	return nil
+}
+
+// isBUGPlusOne returns whether the Grid would be a deadly pattern without the
+// value in the extra Cell, i.e. every other possible value appears exactly twice
+// in each House where it is possible.
+func isBUGPlusOne(grid *Grid, extra Position, value int) bool {
+	for _, house := range allHouses {
+		for candidate := 1; candidate <= 9; candidate++ {
+			count := countPossible(grid, candidate, house)
+			if candidate == value && house.contains(extra) {
+				count--
+			}
+			if count != 0 && count != 2 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// countPossible returns the number of Cells in the House where the value is possible.
+func countPossible(grid *Grid, value int, house House) int {
+	count := 0
+	for _, position := range house.Positions() {
+		if grid.GetCell(position.Row, position.Col).IsPossibleValue(value) {
+			count++
+		}
+	}
+	return count
+}
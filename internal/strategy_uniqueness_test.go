@@ -0,0 +1,205 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniqueRectangleStrategy(t *testing.T) {
+
+	// Define The TestCases, restricting the corners of the rectangle r1c1,r1c5,r2c1,r2c5
+	// in an empty Grid and confining value 1 to the listed Cells of each House
+	testCases := map[string]struct {
+		technique  string
+		difficulty float64
+		corners    map[Position][]int
+		links      map[House][]Position
+		eliminated []Candidate
+		reason     string
+	}{
+		"Type 1": {
+			technique:  TechniqueUniqueRectangle1,
+			difficulty: 4.5,
+			corners:    map[Position][]int{{0, 0}: {1, 2}, {0, 4}: {1, 2}, {1, 0}: {1, 2}},
+			eliminated: []Candidate{newCandidate(1, 4, 1), newCandidate(1, 4, 2)},
+			reason:     "Unique rectangle {1,2} in r1c1,r1c5,r2c1,r2c5 has extra values only in r2c5",
+		},
+		"Type 2": {
+			technique:  TechniqueUniqueRectangle2,
+			difficulty: 4.6,
+			corners:    map[Position][]int{{0, 0}: {1, 2}, {0, 4}: {1, 2}, {1, 0}: {1, 2, 3}, {1, 4}: {1, 2, 3}},
+			eliminated: []Candidate{
+				newCandidate(1, 1, 3), newCandidate(1, 2, 3), newCandidate(1, 3, 3), newCandidate(1, 5, 3),
+				newCandidate(1, 6, 3), newCandidate(1, 7, 3), newCandidate(1, 8, 3),
+			},
+			reason: "Unique rectangle {1,2} in r1c1,r1c5,r2c1,r2c5 has extra value 3 in cells r2c1,r2c5, one of which must hold it",
+		},
+		"Type 3": {
+			technique:  TechniqueUniqueRectangle3,
+			difficulty: 4.7,
+			corners:    map[Position][]int{{0, 0}: {1, 2}, {0, 4}: {1, 2}, {1, 0}: {1, 2, 3}, {1, 4}: {1, 2, 4}, {1, 7}: {3, 4}},
+			eliminated: []Candidate{
+				newCandidate(1, 1, 3), newCandidate(1, 2, 3), newCandidate(1, 3, 3), newCandidate(1, 5, 3), newCandidate(1, 6, 3), newCandidate(1, 8, 3),
+				newCandidate(1, 1, 4), newCandidate(1, 2, 4), newCandidate(1, 3, 4), newCandidate(1, 5, 4), newCandidate(1, 6, 4), newCandidate(1, 8, 4),
+			},
+			reason: "Unique rectangle {1,2} in r1c1,r1c5,r2c1,r2c5 has extra values {3,4} in cells r2c1,r2c5, forming naked subset {3,4} with cells r2c8 in row 2",
+		},
+		"Type 4": {
+			technique:  TechniqueUniqueRectangle4,
+			difficulty: 4.6,
+			corners:    map[Position][]int{{0, 0}: {1, 2}, {0, 4}: {1, 2}},
+			links:      map[House][]Position{{RowHouse, 1}: {{1, 0}, {1, 4}}},
+			eliminated: []Candidate{newCandidate(1, 0, 2), newCandidate(1, 4, 2)},
+			reason:     "Unique rectangle {1,2} in r1c1,r1c5,r2c1,r2c5, where value 1 in row 2 is confined to cells r2c1,r2c5",
+		},
+		"Type 5": {
+			technique:  TechniqueUniqueRectangle5,
+			difficulty: 4.6,
+			corners:    map[Position][]int{{0, 0}: {1, 2}, {1, 4}: {1, 2}, {0, 4}: {1, 2, 3}, {1, 0}: {1, 2, 3}},
+			eliminated: []Candidate{newCandidate(0, 1, 3), newCandidate(0, 2, 3), newCandidate(1, 3, 3), newCandidate(1, 5, 3)},
+			reason:     "Unique rectangle {1,2} in r1c1,r1c5,r2c1,r2c5 has extra value 3 in cells r1c5,r2c1, one of which must hold it",
+		},
+		"Type 6": {
+			technique:  TechniqueUniqueRectangle6,
+			difficulty: 4.7,
+			corners:    map[Position][]int{{0, 0}: {1, 2}, {1, 4}: {1, 2}},
+			links:      map[House][]Position{{RowHouse, 0}: {{0, 0}, {0, 4}}, {RowHouse, 1}: {{1, 0}, {1, 4}}},
+			eliminated: []Candidate{newCandidate(0, 4, 1), newCandidate(1, 0, 1)},
+			reason:     "Unique rectangle {1,2} in r1c1,r1c5,r2c1,r2c5, where value 1 in row 1 and row 2 is confined to the rectangle",
+		},
+		"Hidden": {
+			technique:  TechniqueHiddenUniqueRectangle,
+			difficulty: 4.8,
+			corners:    map[Position][]int{{0, 0}: {1, 2}},
+			links:      map[House][]Position{{RowHouse, 1}: {{1, 0}, {1, 4}}, {ColHouse, 4}: {{0, 4}, {1, 4}}},
+			eliminated: []Candidate{newCandidate(1, 4, 2)},
+			reason:     "Unique rectangle {1,2} in r1c1,r1c5,r2c1,r2c5, where value 1 in row 2 and column 5 is confined to the rectangle",
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			strategy := &uniqueRectangleStrategy{technique: testCase.technique}
+			assert.Equal(t, testCase.technique, strategy.Name())
+			assert.Equal(t, testCase.difficulty, strategy.Difficulty())
+			assert.True(t, requiresUniqueness(strategy))
+
+			// Create the rectangle in an empty Grid
+			grid := NewGrid()
+			confineValue(grid, 1, testCase.links)
+			for position, values := range testCase.corners {
+				restrictCandidates(grid, position.Row, position.Col, values...)
+			}
+
+			// Verify the rectangle is found and the values eliminated
+			steps := strategy.Apply(grid)
+			assert.Len(t, steps, 1)
+			assert.Equal(t, testCase.technique, steps[0].Technique)
+			assert.Equal(t, testCase.reason, steps[0].Reason)
+			assert.ElementsMatch(t, testCase.eliminated, steps[0].Eliminations)
+		})
+	}
+}
+
+func TestUniqueRectangleStrategy_Puzzle(t *testing.T) {
+
+	// Define The TestCases
+	testCases := map[string]struct {
+		technique string
+		puzzle    string
+		solution  string
+	}{
+		"Type 1": {
+			technique: TechniqueUniqueRectangle1,
+			puzzle:    "..73.18....6.7..5.5..6..........3..8.9....2..7.2.8........1...4.....2...91....56.",
+			solution:  "427351896186279453539648127651923748398764215742185639273516984865492371914837562",
+		},
+		"Type 2": {
+			technique: TechniqueUniqueRectangle2,
+			puzzle:    "..582..4........9.1....7..6...9..45.2.4...1.....4..287.8.........6..8......73.92.",
+			solution:  "365829741847613592192547836618972453274385169539461287783294615926158374451736928",
+		},
+		"Type 3": {
+			technique: TechniqueUniqueRectangle3,
+			puzzle:    "..582..4........9.1....7..6...9..45.2.4...1.....4..287.8.........6..8......73.92.",
+			solution:  "365829741847613592192547836618972453274385169539461287783294615926158374451736928",
+		},
+		"Type 4": {
+			technique: TechniqueUniqueRectangle4,
+			puzzle:    "..582..4........9.1....7..6...9..45.2.4...1.....4..287.8.........6..8......73.92.",
+			solution:  "365829741847613592192547836618972453274385169539461287783294615926158374451736928",
+		},
+		"Type 6": {
+			technique: TechniqueUniqueRectangle6,
+			puzzle:    ".89....3..7.5.2.8.......6............54..926..3.17.5.424..1....6....3..........29",
+			solution:  "589641732476532981321798645967254813154389267832176594248917356695423178713865429",
+		},
+		"Hidden": {
+			technique: TechniqueHiddenUniqueRectangle,
+			puzzle:    ".7.9.3..1...8..3..6......5.....5..6.5..23.4.....1.4.2..8..4.6.......1.38......9..",
+			solution:  "875963241421875396639412857942758163517236489368194725183549672794621538256387914",
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			steps := testCollectSteps(testGridFromString(testCase.puzzle), &uniqueRectangleStrategy{technique: testCase.technique})
+			assert.NotEmpty(t, steps)
+			assertValidSteps(t, testCase.solution, steps)
+		})
+	}
+}
+
+func TestBUGPlusOneStrategy(t *testing.T) {
+	strategy := &bugPlusOneStrategy{}
+	assert.Equal(t, TechniqueBUGPlusOne, strategy.Name())
+	assert.Equal(t, 5.6, strategy.Difficulty())
+	assert.True(t, requiresUniqueness(strategy))
+
+	// Unsolve a Binary Universal Grave, where every value is possible exactly twice
+	// in each House, plus value 7 in r5c2
+	solution := "416938275758246193239715846165327489874569321923481657387694512691852734542173968"
+	grid := testGridFromString(solution)
+	unsolveCell(grid, 1, 4, 4, 6)
+	unsolveCell(grid, 1, 5, 4, 6)
+	unsolveCell(grid, 3, 1, 6, 7)
+	unsolveCell(grid, 3, 5, 6, 7)
+	unsolveCell(grid, 4, 1, 4, 6, 7)
+	unsolveCell(grid, 4, 2, 4, 7)
+	unsolveCell(grid, 4, 4, 6, 7)
+	unsolveCell(grid, 6, 2, 4, 7)
+	unsolveCell(grid, 6, 5, 4, 7)
+	unsolveCell(grid, 8, 1, 4, 7)
+	unsolveCell(grid, 8, 4, 4, 7)
+
+	// Verify value 7 is placed in r5c2
+	steps := strategy.Apply(grid)
+	assert.Len(t, steps, 1)
+	assert.Equal(t, []Candidate{newCandidate(4, 1, 7)}, steps[0].Placements)
+	assert.Equal(t, "Every unsolved cell except r5c2 {4,6,7} is bivalue, so it must hold value 7 to avoid a deadly pattern", steps[0].Reason)
+	assertValidSteps(t, solution, steps)
+
+	// Verify nothing is placed with a second Cell which is not bivalue
+	unsolveCell(grid, 4, 1, 4, 6, 7)
+	unsolveCell(grid, 1, 4, 4, 6, 8)
+	assert.Empty(t, strategy.Apply(grid))
+
+	// Unsolve r1c1 as {2,4,6} in the solved Grid, along with 2 other Cells in each
+	// of its Houses as bivalue Cells which could also hold its value 2
+	grid = testGridFromString(testSolvedPuzzle)
+	unsolveCell(grid, 0, 0, 2, 4, 6)
+	unsolveCell(grid, 0, 3, 1, 2)
+	unsolveCell(grid, 0, 6, 2, 3)
+	unsolveCell(grid, 3, 0, 2, 6)
+	unsolveCell(grid, 6, 0, 2, 7)
+	unsolveCell(grid, 1, 1, 2, 7)
+	unsolveCell(grid, 2, 2, 1, 2)
+
+	// Verify nothing is placed, as values 4 and 6 are possible only once in
+	// r1c1's Houses, so the Grid is no deadly pattern without value 2
+	assert.Empty(t, strategy.Apply(grid))
+	assert.Equal(t, 0, grid.GetCell(0, 0).GetValue())
+}
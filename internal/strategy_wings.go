@@ -0,0 +1,175 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Names of the wing techniques, as reported in a SolveResult.
+const (
+	TechniqueXYWing   = "XY-Wing"   // Bivalue pivot with 2 bivalue pincers
+	TechniqueXYZWing  = "XYZ-Wing"  // Trivalue pivot with 2 bivalue pincers
+	TechniqueWXYZWing = "WXYZ-Wing" // Pivot with 3 pincers sharing 4 values
+)
+
+// wingStrategy eliminates a value using a pivot Cell and pincer Cells which the
+// pivot sees, where the N Cells have only N possible values between them.  All
+// but one of those values (z) are "restricted", i.e. every Cell of the wing which
+// could hold the value sees every other, so each can fill at most one Cell of the
+// wing.  The wing must therefore hold z, which is eliminated from any Cell seeing
+// every Cell of the wing where z is possible.
+type wingStrategy struct {
+	name       string
+	difficulty float64
+	cells      int // Number of Cells in the wing, including the pivot
+	pivotMin   int // Minimum number of possible values in the pivot
+	pivotMax   int // Maximum number of possible values in the pivot
+	pincerMax  int // Maximum number of possible values in each pincer
+}
+
+// newXYWingStrategy returns a wingStrategy for a bivalue pivot {x,y} with pincers
+// {x,z} and {y,z}.
+func newXYWingStrategy() *wingStrategy {
+	return &wingStrategy{name: TechniqueXYWing, difficulty: 4.2, cells: 3, pivotMin: 2, pivotMax: 2, pincerMax: 2}
+}
+
+// newXYZWingStrategy returns a wingStrategy for a trivalue pivot {x,y,z} with
+// pincers {x,z} and {y,z}.
+func newXYZWingStrategy() *wingStrategy {
+	return &wingStrategy{name: TechniqueXYZWing, difficulty: 4.4, cells: 3, pivotMin: 3, pivotMax: 3, pincerMax: 2}
+}
+
+// newWXYZWingStrategy returns a wingStrategy for a pivot with 3 pincers sharing
+// the 4 values {w,x,y,z}.
+func newWXYZWingStrategy() *wingStrategy {
+	return &wingStrategy{name: TechniqueWXYZWing, difficulty: 4.6, cells: 4, pivotMin: 2, pivotMax: 4, pincerMax: 4}
+}
+
+func (w *wingStrategy) Name() string        { return w.name }
+func (w *wingStrategy) Difficulty() float64 { return w.difficulty }
+func (w *wingStrategy) Apply(grid *Grid) []Step {
+
+	// Loop over every Cell which could be a pivot
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			pivot := Position{Row: row, Col: col}
+			pivotMask := grid.GetCell(row, col).possibleMask()
+			if pivotMask.count() < w.pivotMin || pivotMask.count() > w.pivotMax {
+				continue
+			}
+
+			// Collect the pivot's peers which could be pincers
+			pincers := []Position{}
+			for _, peer := range pivot.peers() {
+				count := grid.GetCell(peer.Row, peer.Col).possibleMask().count()
+				if count >= 2 && count <= w.pincerMax {
+					pincers = append(pincers, peer)
+				}
+			}
+
+			// Look for pincers completing a wing which makes progress
+			var step *Step
+			combinations(len(pincers), w.cells-1, func(indexes []int) bool {
+				wing := []Position{pivot}
+				for _, index := range indexes {
+					wing = append(wing, pincers[index])
+				}
+				step = w.wingStep(grid, wing)
+				return step != nil
+			})
+			if step != nil {
+				applyStep(grid, *step)
+				return []Step{*step}
+			}
+		}
+	}
+
+	// No wing made progress
+	return nil
+}
+
+// wingStep returns the Step for the wing of Cells (pivot first), or nil if they
+// do not form a wing or it makes no progress.
+func (w *wingStrategy) wingStep(grid *Grid, wing []Position) *Step {
+
+	// The Cells must have exactly N possible values between them
+	masks := make([]candidateMask, len(wing))
+	union := candidateMask(0)
+	for index, position := range wing {
+		masks[index] = grid.GetCell(position.Row, position.Col).possibleMask()
+		union |= masks[index]
+	}
+	if union.count() != len(wing) {
+		return nil
+	}
+
+	// Exactly one value (z) may be unrestricted
+	z := 0
+	var holders []Position
+	for _, value := range union.values() {
+		valueHolders := []Position{}
+		for index, position := range wing {
+			if masks[index].has(value) {
+				valueHolders = append(valueHolders, position)
+			}
+		}
+		if !allSeeEachOther(valueHolders) {
+			if z != 0 {
+				return nil
+			}
+			z, holders = value, valueHolders
+		}
+	}
+	if z == 0 {
+		return nil // Fully restricted Cells are a locked set rather than a wing
+	}
+
+	// Eliminate z from every Cell which sees all the holders of z in the wing
+	eliminations := []Candidate{}
+	for _, position := range holders[0].peers() {
+		if containsPosition(wing, position) || !grid.GetCell(position.Row, position.Col).IsPossibleValue(z) {
+			continue
+		}
+		if seesAll(position, holders) {
+			eliminations = append(eliminations, newCandidate(position.Row, position.Col, z))
+		}
+	}
+	if len(eliminations) == 0 {
+		return nil
+	}
+
+	// Explain the pivot and pincers
+	pincers := make([]string, len(wing)-1)
+	for index, position := range wing[1:] {
+		pincers[index] = fmt.Sprintf("%s %s", position, masks[index+1])
+	}
+	return &Step{
+		Technique:    w.name,
+		Eliminations: eliminations,
+		Cells:        wing,
+		Reason: fmt.Sprintf("Pivot %s %s with pincers %s forces value %d into one of cells %s",
+			wing[0], masks[0], strings.Join(pincers, ", "), z, formatPositions(holders)),
+	}
+}
+
+// allSeeEachOther returns whether every pair of the Positions see each other.
+func allSeeEachOther(positions []Position) bool {
+	for index, position := range positions {
+		for _, other := range positions[index+1:] {
+			if !position.sees(other) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// seesAll returns whether the Position sees every one of the other Positions.
+func seesAll(position Position, others []Position) bool {
+	for _, other := range others {
+		if !position.sees(other) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWingStrategy(t *testing.T) {
+
+	// Define The TestCases, restricting Cells of an empty Grid to form the wing
+	testCases := map[string]struct {
+		strategy   *wingStrategy
+		name       string
+		difficulty float64
+		wing       map[Position][]int
+		eliminated []Candidate
+		reason     string
+	}{
+		"XY-Wing": {
+			strategy:   newXYWingStrategy(),
+			name:       TechniqueXYWing,
+			difficulty: 4.2,
+			wing:       map[Position][]int{{0, 0}: {1, 2}, {0, 4}: {1, 3}, {4, 0}: {2, 3}},
+			eliminated: []Candidate{newCandidate(4, 4, 3)},
+			reason:     "Pivot r1c1 {1,2} with pincers r1c5 {1,3}, r5c1 {2,3} forces value 3 into one of cells r1c5,r5c1",
+		},
+		"XYZ-Wing": {
+			strategy:   newXYZWingStrategy(),
+			name:       TechniqueXYZWing,
+			difficulty: 4.4,
+			wing:       map[Position][]int{{0, 0}: {1, 2, 3}, {0, 4}: {1, 3}, {1, 1}: {2, 3}},
+			eliminated: []Candidate{newCandidate(0, 1, 3), newCandidate(0, 2, 3)},
+			reason:     "Pivot r1c1 {1,2,3} with pincers r1c5 {1,3}, r2c2 {2,3} forces value 3 into one of cells r1c1,r1c5,r2c2",
+		},
+		"WXYZ-Wing": {
+			strategy:   newWXYZWingStrategy(),
+			name:       TechniqueWXYZWing,
+			difficulty: 4.6,
+			wing:       map[Position][]int{{0, 0}: {1, 2, 3}, {0, 4}: {1, 4}, {0, 7}: {2, 4}, {1, 1}: {3, 4}},
+			eliminated: []Candidate{newCandidate(0, 1, 4), newCandidate(0, 2, 4)},
+			reason:     "Pivot r1c1 {1,2,3} with pincers r1c5 {1,4}, r1c8 {2,4}, r2c2 {3,4} forces value 4 into one of cells r1c5,r1c8,r2c2",
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			assert.Equal(t, testCase.name, testCase.strategy.Name())
+			assert.Equal(t, testCase.difficulty, testCase.strategy.Difficulty())
+
+			// Create the wing in an empty Grid
+			grid := NewGrid()
+			for position, values := range testCase.wing {
+				restrictCandidates(grid, position.Row, position.Col, values...)
+			}
+
+			// Verify the wing is found and z eliminated
+			steps := testCase.strategy.Apply(grid)
+			assert.Len(t, steps, 1)
+			assert.Equal(t, testCase.name, steps[0].Technique)
+			assert.Equal(t, testCase.reason, steps[0].Reason)
+			assert.ElementsMatch(t, testCase.eliminated, steps[0].Eliminations)
+			assert.Empty(t, testCase.strategy.Apply(grid))
+		})
+	}
+}
+
+func TestWingStrategy_Puzzle(t *testing.T) {
+
+	// Define The TestCases
+	testCases := map[string]struct {
+		strategy *wingStrategy
+		puzzle   string
+		solution string
+	}{
+		"XY-Wing": {
+			strategy: newXYWingStrategy(),
+			puzzle:   ".2..13.......7..965......1........7..946...5..8...76....3.8.......59.2..249......",
+			solution: "926813745431275896578964312612359478794628153385147629153482967867591234249736581",
+		},
+		"XYZ-Wing": {
+			strategy: newXYZWingStrategy(),
+			puzzle:   "..4..61......5.68..3.2....5..7...........529.6...4.7...5..31...7....2.3....7.....",
+			solution: "594386172172459683836217945917628354483175296625943718259831467741562839368794521",
+		},
+		"WXYZ-Wing": {
+			strategy: newWXYZWingStrategy(),
+			puzzle:   "3.8.......9.6.7....7.4..5...26..3.7.........6...5...299...6...2435..8.......5..4.",
+			solution: "368215794594687231271439568126893475759142386843576129917364852435928617682751943",
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			steps := testCollectSteps(testGridFromString(testCase.puzzle), testCase.strategy)
+			assert.NotEmpty(t, steps)
+			assertValidSteps(t, testCase.solution, steps)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DifficultyTier is the named band a puzzle is published under, assigned from its
+// Rating score by a set of TierThresholds.
+type DifficultyTier int
+
+const (
+	EasyTier    DifficultyTier = iota // Hidden singles only, rated below 2.0 by default
+	MediumTier                        // Naked singles, rated 2.0 to below 2.5
+	HardTier                          // Intersections, pairs, naked triples, X-Wing, Swordfish and single value patterns, rated 2.5 to below 4.0
+	ExpertTier                        // Hidden triples, multi-coloring, finned Swordfish, wings, chains, uniqueness and ALS, rated 4.0 to below 6.0
+	MasterTier                        // Exotic patterns, pattern overlay and forcing chains, rated 6.0 to below 10.0
+	ExtremeTier                       // Guessing, rated 10.0 and up
+)
+
+// difficultyTiers holds every DifficultyTier, from easiest to hardest.
+var difficultyTiers = []DifficultyTier{EasyTier, MediumTier, HardTier, ExpertTier, MasterTier, ExtremeTier}
+
+// String returns the lower case name of the DifficultyTier, as accepted by
+// ParseDifficultyTier().
+func (t DifficultyTier) String() string {
+	switch t {
+	case EasyTier:
+		return "easy"
+	case MediumTier:
+		return "medium"
+	case HardTier:
+		return "hard"
+	case ExpertTier:
+		return "expert"
+	case MasterTier:
+		return "master"
+	case ExtremeTier:
+		return "extreme"
+	default:
+		return fmt.Sprintf("DifficultyTier(%d)", int(t))
+	}
+}
+
+// ParseDifficultyTier returns the DifficultyTier with the specified name, or an
+// error if there is no such tier.
+func ParseDifficultyTier(name string) (DifficultyTier, error) {
+	for _, tier := range difficultyTiers {
+		if tier.String() == name {
+			return tier, nil
+		}
+	}
+	return EasyTier, fmt.Errorf("unknown difficulty tier '%s' must be one of easy,medium,hard,expert,master,extreme", name)
+}
+
+// TierThresholds holds the minimum Rating score of each DifficultyTier above
+// EasyTier, i.e. of the medium, hard, expert, master and extreme tiers in order.
+type TierThresholds [5]float64
+
+// DefaultTierThresholds starts the medium tier at 2.0, between the hidden singles
+// (1.5) and the naked single (2.3), the hard tier at 2.5, just below pointing
+// (2.6), the expert tier at the hard Step difficulty (4.0), the master tier at the
+// SK-Loop (6.0) and the extreme tier at guessing (10.0).
+var DefaultTierThresholds = TierThresholds{2.0, 2.5, DefaultHardStepDifficulty, 6.0, GuessDifficulty}
+
+// ParseTierThresholds returns the TierThresholds from a comma separated list of
+// 5 ascending scores, e.g. "2.0,2.5,4.0,6.0,10.0", or an error if it is invalid.
+func ParseTierThresholds(list string) (TierThresholds, error) {
+	thresholds := TierThresholds{}
+	scores := strings.Split(list, ",")
+	if len(scores) != len(thresholds) {
+		return thresholds, fmt.Errorf("exactly %d tier thresholds expected, encountered %d", len(thresholds), len(scores))
+	}
+	for index, score := range scores {
+		threshold, err := strconv.ParseFloat(strings.TrimSpace(score), 64)
+		if err != nil {
+			return thresholds, fmt.Errorf("encountered unsupported tier threshold '%s': err=%+v", score, err)
+		}
+		if index > 0 && threshold <= thresholds[index-1] {
+			return thresholds, fmt.Errorf("tier thresholds must ascend, encountered %g after %g", threshold, thresholds[index-1])
+		}
+		thresholds[index] = threshold
+	}
+	return thresholds, nil
+}
+
+// String returns the TierThresholds as accepted by ParseTierThresholds().
+func (t TierThresholds) String() string {
+	scores := make([]string, len(t))
+	for index, threshold := range t {
+		scores[index] = strconv.FormatFloat(threshold, 'f', 1, 64)
+	}
+	return strings.Join(scores, ",")
+}
+
+// Tier returns the DifficultyTier of the Rating score, i.e. the hardest tier
+// whose threshold it reaches.
+func (t TierThresholds) Tier(score float64) DifficultyTier {
+	tier := EasyTier
+	for index, threshold := range t {
+		if score >= threshold {
+			tier = difficultyTiers[index+1]
+		}
+	}
+	return tier
+}
@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDifficultyTier(t *testing.T) {
+	assert.Equal(t, "easy", EasyTier.String())
+	assert.Equal(t, "extreme", ExtremeTier.String())
+	assert.Equal(t, "DifficultyTier(9)", DifficultyTier(9).String())
+
+	// Verify each tier can be parsed from its name
+	for _, tier := range difficultyTiers {
+		parsed, err := ParseDifficultyTier(tier.String())
+		assert.NoError(t, err)
+		assert.Equal(t, tier, parsed)
+	}
+	_, err := ParseDifficultyTier("diabolical")
+	assert.EqualError(t, err, "unknown difficulty tier 'diabolical' must be one of easy,medium,hard,expert,master,extreme")
+}
+
+func TestParseTierThresholds(t *testing.T) {
+
+	// Define The TestCases
+	testCases := map[string]struct {
+		list       string
+		thresholds TierThresholds
+		expectErr  string
+	}{
+		"Default": {
+			list:       "2.0,2.5,4.0,6.0,10.0",
+			thresholds: DefaultTierThresholds,
+		},
+		"Spaces": {
+			list:       "1.5, 2, 3, 4.5, 8",
+			thresholds: TierThresholds{1.5, 2.0, 3.0, 4.5, 8.0},
+		},
+		"Too Few": {
+			list:      "2.0,2.5,4.0,6.0",
+			expectErr: "exactly 5 tier thresholds expected, encountered 4",
+		},
+		"Unsupported": {
+			list:      "2.0,2.5,four,6.0,10.0",
+			expectErr: "encountered unsupported tier threshold 'four'",
+		},
+		"Descending": {
+			list:      "2.0,2.5,4.0,3.0,10.0",
+			expectErr: "tier thresholds must ascend, encountered 3 after 4",
+		},
+	}
+
+	// Execute The TestCases
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			thresholds, err := ParseTierThresholds(testCase.list)
+			if testCase.expectErr != "" {
+				assert.ErrorContains(t, err, testCase.expectErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.thresholds, thresholds)
+			}
+		})
+	}
+}
+
+func TestTierThresholds_String(t *testing.T) {
+	assert.Equal(t, "2.0,2.5,4.0,6.0,10.0", DefaultTierThresholds.String())
+}
+
+func TestTierThresholds_Tier(t *testing.T) {
+	for score, tier := range map[float64]DifficultyTier{
+		1.2:  EasyTier,
+		1.5:  EasyTier,
+		2.3:  MediumTier,
+		2.6:  HardTier,
+		3.9:  HardTier,
+		4.0:  ExpertTier,
+		5.9:  ExpertTier,
+		6.3:  MasterTier,
+		7.0:  MasterTier,
+		10.0: ExtremeTier,
+	} {
+		assert.Equal(t, tier, DefaultTierThresholds.Tier(score), score)
+	}
+}
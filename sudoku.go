@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"log"
+	"strings"
 
 	sudoku "github.com/tminke/go-sudoku/internal"
 )
@@ -13,8 +14,62 @@ func main() {
 	csvFile := flag.String("file", "sudoku.csv", "Path/Name of the CSV file containing the sudoku puzzle (default = sudoku.csv).")
 	maxIterations := flag.Int("iter", 50, "The maximum number of iterations before abandoning the solve (default = 50).")
 	verbose := flag.Bool("verbose", false, "Whether or not to log the individual steps in the solve (default = false).")
+	checkUnique := flag.Bool("check-unique", false, "Only check whether the puzzle has a unique solution, without solving it (default = false).")
+	engine := flag.String("engine", "logic", "The solving engine to use, either 'logic' or 'dlx' (default = logic).")
+	assumeUnique := flag.Bool("assume-unique", false, "Whether to apply techniques which assume the puzzle has a unique solution (default = false).")
+	tier := flag.String("tier", "standard", "The highest tier of techniques to apply, either 'standard' or 'exotic' (default = standard).")
+	grade := flag.Bool("grade", false, "Only rate the difficulty of the puzzle, without reporting its solution (default = false).")
+	hardWeight := flag.Float64("hard-weight", 0, "The extra rating for each hard step beyond the first when grading (default = 0).")
+	tiers := flag.String("tiers", sudoku.DefaultTierThresholds.String(), "The minimum rating of the medium, hard, expert, master and extreme tiers when grading.")
+	calibrate := flag.String("calibrate", "", "Only grade the samples in the directory named after their tier, reporting any rated outside it.")
+	hint := flag.Int("hint", 0, "Only give a hint for the next step, revealing 1=house, 2=technique, 3=cells, or 4=deduction (default = 0, solve).")
 	flag.Parse()
 
+	// Validate The Enumerated Flags Before Any Mode Runs
+	if *engine != "logic" && *engine != "dlx" {
+		log.Fatalf("Unsupported engine '%s' must be one of logic,dlx", *engine)
+	}
+	strategyTier, err := sudoku.ParseStrategyTier(*tier)
+	if err != nil {
+		log.Fatalf("Invalid tier: %v", err)
+	}
+
+	// Configure The Logical Solver
+	solver := sudoku.NewSolver(*maxIterations, *verbose)
+	solver.SetAssumeUnique(*assumeUnique)
+	solver.SetTier(strategyTier)
+
+	// Configure The Rater
+	rater := sudoku.NewRater(solver)
+	rater.SetHardStepWeight(*hardWeight)
+	thresholds, err := sudoku.ParseTierThresholds(*tiers)
+	if err != nil {
+		log.Fatalf("Invalid tiers: %v", err)
+	}
+	rater.SetTierThresholds(thresholds)
+
+	// Only Check The Samples Are Rated In Their Named Tiers If Requested
+	if *calibrate != "" {
+		calibrations, err := rater.Calibrate(*calibrate)
+		if err != nil {
+			log.Fatalf("Unable to read the samples: %v", err)
+		}
+		mismatches := []string{}
+		for _, calibration := range calibrations {
+			if !calibration.Matches() {
+				mismatches = append(mismatches, calibration.File)
+			}
+			log.Printf("%s", calibration)
+		}
+		if len(mismatches) > 0 {
+			log.Printf("Calibration: %d of %d samples are outside their named tier: %s",
+				len(mismatches), len(calibrations), strings.Join(mismatches, ", "))
+		} else {
+			log.Printf("Calibration: all %d samples are in their named tier", len(calibrations))
+		}
+		return
+	}
+
 	// Create A Grid From The Specified Sudoku CSV File
 	grid, err := sudoku.NewGridFromCsv(*csvFile)
 	if err != nil {
@@ -22,10 +77,60 @@ func main() {
 	}
 	log.Printf("Problem:\n\n%s\n", grid)
 
-	// Create A New Sudoku Solver
-	solver := sudoku.NewSolver(*maxIterations, *verbose)
+	// Only Report The Number Of Solutions If Requested
+	if *checkUnique {
+		switch sudoku.CountSolutions(grid, 2) {
+		case 0:
+			log.Printf("The puzzle has no solution")
+		case 1:
+			log.Printf("The puzzle has a unique solution")
+		default:
+			log.Printf("The puzzle has multiple solutions")
+		}
+		return
+	}
+
+	// Only Rate The Difficulty Of The Puzzle If Requested
+	if *grade {
+		rating, err := rater.Rate(grid)
+		if err != nil {
+			log.Fatalf("Unable to rate the puzzle: %v", err)
+		}
+		log.Printf("Rating: %s", rating)
+		return
+	}
+
+	// Only Give A Hint For The Next Step If Requested
+	if *hint != 0 {
+		level := sudoku.HintLevel(*hint)
+		if !level.IsValid() {
+			log.Fatalf("Unsupported hint level %d must be one of 1-4", *hint)
+		}
+		if next, found := solver.Hint(grid, level); found {
+			log.Printf("Hint: %s", next)
+		} else {
+			log.Printf("No hint available, the puzzle is solved or no technique applies")
+		}
+		return
+	}
+
+	// Solve The Sudoku Puzzle With The Selected Engine
+	switch *engine {
+	case "logic":
+		result, err := solver.Solve(grid)
+		if err != nil {
+			log.Printf("Unable to solve the puzzle: %v", err)
+		}
+		log.Printf("Finished solving: %s", result)
+		if result.Status == sudoku.Solved {
+			log.Printf("Solved by: %s", result.Method())
+		}
+	case "dlx":
+		if !sudoku.NewDancingLinks().Solve(grid) {
+			log.Printf("No solution exists for the puzzle")
+		}
+	}
 
-	// Solve The Sudoku Puzzle & Log The Result
-	solver.Solve(grid)
+	// Log The Result
 	log.Printf("Solution:\n\n%s\n", grid)
 }